@@ -48,6 +48,21 @@ type ElasticIPParameters struct {
 	// Bandwidth specifies the bandwidth configuration.
 	// +kubebuilder:validation:Required
 	Bandwidth BandwidthConfig `json:"bandwidth"`
+
+	// Tags of the EIP. Only the tags listed here are managed, other
+	// tags of the EIP are left untouched. Removing a tag from this map
+	// removes it from the EIP.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the EIP,
+	// or 0 for the default enterprise project. Defaults to the enterprise
+	// project of the ProviderConfig. Changing it migrates the EIP to the new
+	// enterprise project, its bandwidth stays where it is.
+	// +optional
+	// +kubebuilder:validation:MaxLength=36
+	EnterpriseProjectID *string `json:"enterpriseProjectId,omitempty"`
 }
 
 // ElasticIPObservation are the observable fields of a ElasticIP.
@@ -75,12 +90,39 @@ type ElasticIPObservation struct {
 
 	// BandwidthShareType is the share type of the bandwidth.
 	BandwidthShareType string `json:"bandwidthShareType,omitempty"`
+
+	// Tags are the observed values of the tags managed by the managed
+	// resource.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the EIP.
+	EnterpriseProjectID string `json:"enterpriseProjectId,omitempty"`
+}
+
+// An ElasticIPImportSelector selects an existing EIP to import. An EIP matches
+// if all fields of the selector match.
+// +kubebuilder:validation:MinProperties=1
+type ElasticIPImportSelector struct {
+	// IPAddress is the public IP address of the EIP.
+	// +optional
+	IPAddress *string `json:"ipAddress,omitempty"`
+
+	// Tags the EIP must carry.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A ElasticIPSpec defines the desired state of a ElasticIP.
 type ElasticIPSpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
 	ForProvider              ElasticIPParameters `json:"forProvider"`
+
+	// ImportSelector imports an existing EIP instead of creating one. It is
+	// resolved once, while the managed resource has no external name, and the ID
+	// of the matching EIP is pinned in the external name. The import fails if no
+	// or more than one EIP matches.
+	// +optional
+	ImportSelector *ElasticIPImportSelector `json:"importSelector,omitempty"`
 }
 
 // A ElasticIPStatus represents the observed state of a ElasticIP.
@@ -54,6 +54,33 @@ func (in *ElasticIP) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticIPImportSelector) DeepCopyInto(out *ElasticIPImportSelector) {
+	*out = *in
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPImportSelector.
+func (in *ElasticIPImportSelector) DeepCopy() *ElasticIPImportSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticIPImportSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticIPList) DeepCopyInto(out *ElasticIPList) {
 	*out = *in
@@ -89,6 +116,13 @@ func (in *ElasticIPList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticIPObservation) DeepCopyInto(out *ElasticIPObservation) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPObservation.
@@ -106,6 +140,18 @@ func (in *ElasticIPParameters) DeepCopyInto(out *ElasticIPParameters) {
 	*out = *in
 	in.PublicIP.DeepCopyInto(&out.PublicIP)
 	out.Bandwidth = in.Bandwidth
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnterpriseProjectID != nil {
+		in, out := &in.EnterpriseProjectID, &out.EnterpriseProjectID
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPParameters.
@@ -123,6 +169,11 @@ func (in *ElasticIPSpec) DeepCopyInto(out *ElasticIPSpec) {
 	*out = *in
 	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ImportSelector != nil {
+		in, out := &in.ImportSelector, &out.ImportSelector
+		*out = new(ElasticIPImportSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPSpec.
@@ -139,7 +190,7 @@ func (in *ElasticIPSpec) DeepCopy() *ElasticIPSpec {
 func (in *ElasticIPStatus) DeepCopyInto(out *ElasticIPStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticIPStatus.
@@ -62,6 +62,21 @@ type NATGatewayParameters struct {
 	// SubnetIDSelector selects a reference to a Subnet.
 	// +optional
 	SubnetIDSelector *xpv1.NamespacedSelector `json:"subnetIdSelector,omitempty"`
+
+	// Tags of the NAT Gateway. Only the tags listed here are managed, other
+	// tags of the NAT Gateway are left untouched. Removing a tag from this map
+	// removes it from the NAT Gateway.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the NAT
+	// Gateway, or 0 for the default enterprise project. Defaults to the
+	// enterprise project of the ProviderConfig. Changing it migrates the NAT
+	// Gateway to the new enterprise project.
+	// +optional
+	// +kubebuilder:validation:MaxLength=36
+	EnterpriseProjectID *string `json:"enterpriseProjectId,omitempty"`
 }
 
 // NATGatewayObservation are the observable fields of a NATGateway.
@@ -80,12 +95,40 @@ type NATGatewayObservation struct {
 
 	// SubnetID is the actual Subnet ID of the NAT Gateway.
 	SubnetID string `json:"subnetId,omitempty"`
+
+	// Tags are the observed values of the tags managed by the managed
+	// resource.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the NAT
+	// Gateway.
+	EnterpriseProjectID string `json:"enterpriseProjectId,omitempty"`
+}
+
+// A NATGatewayImportSelector selects an existing NAT Gateway to import. A NAT
+// Gateway matches if all fields of the selector match.
+// +kubebuilder:validation:MinProperties=1
+type NATGatewayImportSelector struct {
+	// Name of the NAT Gateway.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Tags the NAT Gateway must carry.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A NATGatewaySpec defines the desired state of a NATGateway.
 type NATGatewaySpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
 	ForProvider              NATGatewayParameters `json:"forProvider"`
+
+	// ImportSelector imports an existing NAT Gateway instead of creating one. It
+	// is resolved once, while the managed resource has no external name, and the
+	// ID of the matching NAT Gateway is pinned in the external name. The import
+	// fails if no or more than one NAT Gateway matches.
+	// +optional
+	ImportSelector *NATGatewayImportSelector `json:"importSelector,omitempty"`
 }
 
 // A NATGatewayStatus represents the observed state of a NATGateway.
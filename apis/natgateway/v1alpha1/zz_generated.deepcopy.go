@@ -40,6 +40,33 @@ func (in *NATGateway) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayImportSelector) DeepCopyInto(out *NATGatewayImportSelector) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayImportSelector.
+func (in *NATGatewayImportSelector) DeepCopy() *NATGatewayImportSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayImportSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NATGatewayList) DeepCopyInto(out *NATGatewayList) {
 	*out = *in
@@ -75,6 +102,13 @@ func (in *NATGatewayList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NATGatewayObservation) DeepCopyInto(out *NATGatewayObservation) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayObservation.
@@ -115,6 +149,18 @@ func (in *NATGatewayParameters) DeepCopyInto(out *NATGatewayParameters) {
 		*out = new(v1.NamespacedSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnterpriseProjectID != nil {
+		in, out := &in.EnterpriseProjectID, &out.EnterpriseProjectID
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayParameters.
@@ -132,6 +178,11 @@ func (in *NATGatewaySpec) DeepCopyInto(out *NATGatewaySpec) {
 	*out = *in
 	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ImportSelector != nil {
+		in, out := &in.ImportSelector, &out.ImportSelector
+		*out = new(NATGatewayImportSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewaySpec.
@@ -148,7 +199,7 @@ func (in *NATGatewaySpec) DeepCopy() *NATGatewaySpec {
 func (in *NATGatewayStatus) DeepCopyInto(out *NATGatewayStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayStatus.
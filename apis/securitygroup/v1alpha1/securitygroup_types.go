@@ -23,6 +23,21 @@ type SecurityGroupParameters struct {
 	// +optional
 	// +kubebuilder:validation:MaxLength=255
 	Description *string `json:"description,omitempty"`
+
+	// Tags of the security group. Only the tags listed here are managed, other
+	// tags of the security group are left untouched. Removing a tag from this map
+	// removes it from the security group.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the security group,
+	// or 0 for the default enterprise project. Defaults to the enterprise
+	// project of the ProviderConfig. Changing it migrates the security group
+	// to the new enterprise project.
+	// +optional
+	// +kubebuilder:validation:MaxLength=36
+	EnterpriseProjectID *string `json:"enterpriseProjectId,omitempty"`
 }
 
 // SecurityGroupObservation are the observable fields of a SecurityGroup.
@@ -32,12 +47,39 @@ type SecurityGroupObservation struct {
 
 	// Status indicates the current status of the SecurityGroup.
 	Status string `json:"status,omitempty"`
+
+	// Tags are the observed values of the tags managed by the managed
+	// resource.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the security group.
+	EnterpriseProjectID string `json:"enterpriseProjectId,omitempty"`
+}
+
+// A SecurityGroupImportSelector selects an existing security group to import. A
+// security group matches if all fields of the selector match.
+// +kubebuilder:validation:MinProperties=1
+type SecurityGroupImportSelector struct {
+	// Name of the security group.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Tags the security group must carry.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A SecurityGroupSpec defines the desired state of a SecurityGroup.
 type SecurityGroupSpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
 	ForProvider              SecurityGroupParameters `json:"forProvider"`
+
+	// ImportSelector imports an existing security group instead of creating one.
+	// It is resolved once, while the managed resource has no external name, and
+	// the ID of the matching security group is pinned in the external name. The
+	// import fails if no or more than one security group matches.
+	// +optional
+	ImportSelector *SecurityGroupImportSelector `json:"importSelector,omitempty"`
 }
 
 // A SecurityGroupStatus represents the observed state of a SecurityGroup.
@@ -55,10 +55,21 @@ type SecurityGroupRuleParameters struct {
 	Multiport *string `json:"multiport,omitempty"`
 
 	// RemoteIPPrefix specifies the remote IP prefix (CIDR).
+	// +crossplane:generate:reference:type=github.com/peertechde/provider-opentelekomcloud/apis/subnet/v1alpha1.Subnet
+	// +crossplane:generate:reference:extractor=github.com/peertechde/provider-opentelekomcloud/apis/subnet/v1alpha1.IPv6CIDR()
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="RemoteIPPrefix is immutable"
 	RemoteIPPrefix *string `json:"remoteIpPrefix,omitempty"`
 
+	// RemoteIPPrefixRef references a dual-stack Subnet to retrieve its IPv6
+	// CIDR. The Ethertype of the rule must be IPv6.
+	// +optional
+	RemoteIPPrefixRef *xpv1.NamespacedReference `json:"remoteIpPrefixRef,omitempty"`
+
+	// RemoteIPPrefixSelector selects a reference to a dual-stack Subnet.
+	// +optional
+	RemoteIPPrefixSelector *xpv1.NamespacedSelector `json:"remoteIpPrefixSelector,omitempty"`
+
 	// RemoteGroupID specifies the ID of the remote security group.
 	// +crossplane:generate:reference:type=github.com/peertechde/provider-opentelekomcloud/apis/securitygroup/v1alpha1.SecurityGroup
 	// +optional
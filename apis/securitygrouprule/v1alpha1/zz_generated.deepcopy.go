@@ -125,6 +125,16 @@ func (in *SecurityGroupRuleParameters) DeepCopyInto(out *SecurityGroupRuleParame
 		*out = new(string)
 		**out = **in
 	}
+	if in.RemoteIPPrefixRef != nil {
+		in, out := &in.RemoteIPPrefixRef, &out.RemoteIPPrefixRef
+		*out = new(v1.NamespacedReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteIPPrefixSelector != nil {
+		in, out := &in.RemoteIPPrefixSelector, &out.RemoteIPPrefixSelector
+		*out = new(v1.NamespacedSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RemoteGroupID != nil {
 		in, out := &in.RemoteGroupID, &out.RemoteGroupID
 		*out = new(string)
@@ -10,6 +10,7 @@ import (
 	"context"
 	reference "github.com/crossplane/crossplane-runtime/v2/pkg/reference"
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/securitygroup/v1alpha1"
+	v1alpha11 "github.com/peertechde/provider-opentelekomcloud/apis/subnet/v1alpha1"
 	errors "github.com/pkg/errors"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -38,6 +39,23 @@ func (mg *SecurityGroupRule) ResolveReferences(ctx context.Context, c client.Rea
 	mg.Spec.ForProvider.SecurityGroupID = rsp.ResolvedValue
 	mg.Spec.ForProvider.SecurityGroupIDRef = rsp.ResolvedReference
 
+	rsp, err = r.Resolve(ctx, reference.NamespacedResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.RemoteIPPrefix),
+		Extract:      v1alpha11.IPv6CIDR(),
+		Namespace:    mg.GetNamespace(),
+		Reference:    mg.Spec.ForProvider.RemoteIPPrefixRef,
+		Selector:     mg.Spec.ForProvider.RemoteIPPrefixSelector,
+		To: reference.To{
+			List:    &v1alpha11.SubnetList{},
+			Managed: &v1alpha11.Subnet{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.RemoteIPPrefix")
+	}
+	mg.Spec.ForProvider.RemoteIPPrefix = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.RemoteIPPrefixRef = rsp.ResolvedReference
+
 	rsp, err = r.Resolve(ctx, reference.NamespacedResolutionRequest{
 		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.RemoteGroupID),
 		Extract:      reference.ExternalName(),
@@ -8,6 +8,8 @@ import (
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	xpv2 "github.com/crossplane/crossplane-runtime/v2/apis/common/v2"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 )
 
 // SubnetParameters defines the desired state of a Subnet.
@@ -44,6 +46,11 @@ type SubnetParameters struct {
 	// +optional
 	DHCPEnable *bool `json:"dhcpEnable,omitempty"`
 
+	// IPv6Enable specifies whether the Subnet is dual-stack, i.e. whether an
+	// IPv6 CIDR block is assigned to it in addition to its IPv4 CIDR.
+	// +optional
+	IPv6Enable *bool `json:"ipv6Enable,omitempty"`
+
 	// PrimaryDNS is the IP address of the primary DNS server.
 	// +optional
 	PrimaryDNS *string `json:"primaryDns,omitempty"`
@@ -60,6 +67,13 @@ type SubnetParameters struct {
 	// Description is the description of the Subnet.
 	// +optional
 	Description *string `json:"description,omitempty"`
+
+	// Tags of the subnet. Only the tags listed here are managed, other
+	// tags of the subnet are left untouched. Removing a tag from this map
+	// removes it from the subnet.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // SubnetObservation are the observable fields of a Subnet.
@@ -78,12 +92,52 @@ type SubnetObservation struct {
 
 	// VPCID is the actual VPC ID of the Subnet.
 	VPCID string `json:"vpcId,omitempty"`
+
+	// IPv6CIDR is the IPv6 CIDR block assigned to the Subnet, if IPv6 is
+	// enabled.
+	IPv6CIDR string `json:"ipv6Cidr,omitempty"`
+
+	// IPv6GatewayIP is the IPv6 gateway address of the Subnet, if IPv6 is
+	// enabled.
+	IPv6GatewayIP string `json:"ipv6GatewayIp,omitempty"`
+
+	// Tags are the observed values of the tags managed by the managed
+	// resource.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A SubnetImportSelector selects an existing subnet to import. A subnet matches
+// if all fields of the selector match.
+// +kubebuilder:validation:MinProperties=1
+type SubnetImportSelector struct {
+	// Name of the subnet.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// CIDR of the subnet.
+	// +optional
+	CIDR *string `json:"cidr,omitempty"`
+
+	// VPCID is the ID of the VPC of the subnet.
+	// +optional
+	VPCID *string `json:"vpcId,omitempty"`
+
+	// Tags the subnet must carry.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A SubnetSpec defines the desired state of a Subnet.
 type SubnetSpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
 	ForProvider              SubnetParameters `json:"forProvider"`
+
+	// ImportSelector imports an existing subnet instead of creating one. It is
+	// resolved once, while the managed resource has no external name, and the ID
+	// of the matching subnet is pinned in the external name. The import fails if
+	// no or more than one subnet matches.
+	// +optional
+	ImportSelector *SubnetImportSelector `json:"importSelector,omitempty"`
 }
 
 // A SubnetStatus represents the observed state of a Subnet.
@@ -132,3 +186,16 @@ var (
 func init() {
 	SchemeBuilder.Register(&Subnet{}, &SubnetList{})
 }
+
+// IPv6CIDR returns a reference extractor that extracts the IPv6 CIDR of a
+// Subnet, e.g. to reference it as the remote IP prefix of a
+// SecurityGroupRule.
+func IPv6CIDR() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		s, ok := mg.(*Subnet)
+		if !ok {
+			return ""
+		}
+		return s.Status.AtProvider.IPv6CIDR
+	}
+}
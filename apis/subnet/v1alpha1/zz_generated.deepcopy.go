@@ -40,6 +40,43 @@ func (in *Subnet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetImportSelector) DeepCopyInto(out *SubnetImportSelector) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.VPCID != nil {
+		in, out := &in.VPCID, &out.VPCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetImportSelector.
+func (in *SubnetImportSelector) DeepCopy() *SubnetImportSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetImportSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetList) DeepCopyInto(out *SubnetList) {
 	*out = *in
@@ -75,6 +112,13 @@ func (in *SubnetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetObservation) DeepCopyInto(out *SubnetObservation) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetObservation.
@@ -105,6 +149,11 @@ func (in *SubnetParameters) DeepCopyInto(out *SubnetParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IPv6Enable != nil {
+		in, out := &in.IPv6Enable, &out.IPv6Enable
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PrimaryDNS != nil {
 		in, out := &in.PrimaryDNS, &out.PrimaryDNS
 		*out = new(string)
@@ -125,6 +174,13 @@ func (in *SubnetParameters) DeepCopyInto(out *SubnetParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetParameters.
@@ -142,6 +198,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
 	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ImportSelector != nil {
+		in, out := &in.ImportSelector, &out.ImportSelector
+		*out = new(SubnetImportSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -158,7 +219,7 @@ func (in *SubnetSpec) DeepCopy() *SubnetSpec {
 func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
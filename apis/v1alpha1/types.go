@@ -20,12 +20,119 @@ type ProviderCredentials struct {
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// AuthMethod is the method used to authenticate against the identity service.
+type AuthMethod string
+
+// Supported authentication methods.
+const (
+	// AuthMethodAKSK authenticates with an access key and secret key. The
+	// credentials must contain the keys accessKey and secretKey.
+	AuthMethodAKSK AuthMethod = "AKSK"
+
+	// AuthMethodPassword authenticates with the password of an IAM user of
+	// the configured domain. The credentials must contain the keys username
+	// and password.
+	AuthMethodPassword AuthMethod = "Password"
+
+	// AuthMethodToken authenticates with a pre-issued IAM token. The
+	// credentials must contain the key token.
+	AuthMethodToken AuthMethod = "Token"
+)
+
+// AssumeAgency configures an IAM agency that is assumed with the base
+// credentials. The provider then manages resources with the temporary
+// credentials of the agency.
+type AssumeAgency struct {
+	// DomainName is the name of the domain that created the agency, i.e. the
+	// domain owning the project resources are managed in.
+	// +kubebuilder:validation:Required
+	DomainName string `json:"domainName"`
+
+	// AgencyName is the name of the agency to assume.
+	// +kubebuilder:validation:Required
+	AgencyName string `json:"agencyName"`
+
+	// Duration is how long the temporary agency credentials are valid. They
+	// are refreshed shortly before they expire. Must be between 15m and 24h.
+	// +optional
+	// +kubebuilder:default="1h"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// Transport configures how the provider connects to Open Telekom Cloud.
+type Transport struct {
+	// ProxyURL is the URL of the HTTP proxy all requests are sent through.
+	// Defaults to the proxy configured by the HTTPS_PROXY, HTTP_PROXY and
+	// NO_PROXY environment variables.
+	// +optional
+	ProxyURL *string `json:"proxyURL,omitempty"`
+
+	// CABundleSecretRef references a secret key holding PEM encoded CA
+	// certificates. They are trusted in addition to the system CAs, e.g. to
+	// allow TLS inspection by an egress proxy.
+	// +optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertificateSecretRef references a kubernetes.io/tls secret whose
+	// tls.crt and tls.key are presented as client certificate.
+	// +optional
+	ClientCertificateSecretRef *xpv1.SecretReference `json:"clientCertificateSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables the verification of server certificates.
+	// Never use it outside of lab environments.
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// RetryPolicy configures how failed requests to Open Telekom Cloud are
+// retried. Throttled requests (429) are always retryable. Network errors and
+// transient server errors (500, 502, 503, 504) are only retried for
+// idempotent methods, i.e. not for POST.
+type RetryPolicy struct {
+	// MaxRetries is how often a failed request is retried. Set it to 0 to
+	// disable retries.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// BaseDelay is the delay before the first retry. It doubles with every
+	// further retry, and a random jitter is applied.
+	// +optional
+	// +kubebuilder:default="500ms"
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+
+	// MaxDelay caps the delay between two attempts, including delays
+	// requested by a Retry-After header.
+	// +optional
+	// +kubebuilder:default="30s"
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// Logging configures what the provider logs about the requests it sends to
+// Open Telekom Cloud.
+type Logging struct {
+	// Wire logs the method, URL, status, duration and bodies of every request
+	// and response. Signatures, tokens and secret fields are redacted.
+	// Defaults to the --wire-logging flag of the provider.
+	// +optional
+	Wire *bool `json:"wire,omitempty"`
+}
+
 type ProviderConfigSpec struct {
 	// IdentityEndpoint is the OpenStack identity endpoint.
-	// Defaults to OTC public endpoint if not specified.
+	// Defaults to the public identity endpoint of the Region if not specified.
 	// +optional
 	IdentityEndpoint *string `json:"identityEndpoint,omitempty"`
 
+	// Endpoints overrides the endpoints of individual services. Keys are the
+	// service types of the OTC service catalog, e.g. vpc, network or nat, and
+	// iam for the identity service. Values replace the endpoint the catalog
+	// returns for the service type. A missing trailing slash is added.
+	// +optional
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
 	// DomainName is the OpenStack domain name.
 	// +kubebuilder:validation:Required
 	DomainName string `json:"domainName"`
@@ -38,6 +145,65 @@ type ProviderConfigSpec struct {
 	// +kubebuilder:validation:Required
 	Region string `json:"region"`
 
+	// AuthMethod selects how the provider authenticates. Each method expects
+	// its own keys in the credentials: AKSK expects accessKey and secretKey,
+	// Password expects username and password, and Token expects token.
+	// +optional
+	// +kubebuilder:default=AKSK
+	// +kubebuilder:validation:Enum=AKSK;Password;Token
+	AuthMethod AuthMethod `json:"authMethod,omitempty"`
+
+	// AssumeAgency makes the provider exchange the credentials for the
+	// temporary credentials of an IAM agency. DomainName then refers to the
+	// domain of the base credentials, while ProjectID refers to the project
+	// in the delegating domain.
+	// +optional
+	AssumeAgency *AssumeAgency `json:"assumeAgency,omitempty"`
+
+	// Transport configures how the provider connects to Open Telekom Cloud.
+	// +optional
+	Transport *Transport `json:"transport,omitempty"`
+
+	// Retry configures how failed requests are retried.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Logging configures what is logged about the requests to Open Telekom
+	// Cloud.
+	// +optional
+	Logging *Logging `json:"logging,omitempty"`
+
+	// DeletionProtection is the default deletion protection of the managed
+	// resources using this ProviderConfig. Protected managed resources are
+	// not deleted until the opentelekomcloud.crossplane.io/deletion-protection
+	// annotation is set to false.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// DefaultTags are added to the tags of every resource that supports tags.
+	// Tags of a managed resource take precedence over default tags with the
+	// same key. Changing a default tag updates the tags of all existing
+	// resources.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+
+	// NamePrefix is prepended to the names of the resources created with this
+	// ProviderConfig, e.g. team-a- turns the VPC name main into team-a-main.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]*$`
+	NamePrefix *string `json:"namePrefix,omitempty"`
+
+	// EnterpriseProjectID is the default enterprise project of the VPCs,
+	// security groups, EIPs and NAT Gateways managed with this
+	// ProviderConfig, unless they set their own. Subnets belong to the
+	// enterprise project of their VPC. Changing the default migrates all
+	// existing resources that do not set their own enterprise project.
+	// +optional
+	// +kubebuilder:validation:MaxLength=36
+	EnterpriseProjectID *string `json:"enterpriseProjectId,omitempty"`
+
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 }
@@ -46,6 +212,7 @@ type ProviderConfigSpec struct {
 // +kubebuilder:storageversion
 
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,opentelekomcloud}
@@ -95,6 +262,7 @@ type ProviderConfigUsageList struct {
 // +kubebuilder:object:root=true
 
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,opentelekomcloud}
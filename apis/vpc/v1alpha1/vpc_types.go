@@ -25,10 +25,37 @@ type VPCParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="CIDR is immutable"
 	CIDR string `json:"cidr"`
 
+	// SecondaryCIDRs extend the VPC with further CIDR blocks, e.g. when its
+	// CIDR runs out of addresses. Only the secondary CIDRs listed here are
+	// managed, other secondary CIDRs of the VPC are left untouched. Removing
+	// a CIDR from this list removes it from the VPC, which fails while
+	// subnets use it. Each CIDR is added with a request of its own, so a
+	// CIDR that exceeds the quota of the project is reported on its own.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=5
+	SecondaryCIDRs []string `json:"secondaryCidrs,omitempty"`
+
 	// Description provides supplementary information about the VPC.
 	// +optional
 	// +kubebuilder:validation:MaxLength=255
 	Description *string `json:"description,omitempty"`
+
+	// Tags of the VPC. Only the tags listed here are managed, other
+	// tags of the VPC are left untouched. Removing a tag from this map
+	// removes it from the VPC.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=20
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the VPC,
+	// or 0 for the default enterprise project. Defaults to the enterprise
+	// project of the ProviderConfig. Changing it migrates the VPC to the new
+	// enterprise project. The subnets of the VPC belong to its enterprise
+	// project.
+	// +optional
+	// +kubebuilder:validation:MaxLength=36
+	EnterpriseProjectID *string `json:"enterpriseProjectId,omitempty"`
 }
 
 // VPCObservation are the observable fields of a VPC.
@@ -36,17 +63,56 @@ type VPCObservation struct {
 	// ID is the unique identifier of the VPC.
 	ID string `json:"id,omitempty"`
 
-	// Status indicates the current status of the VPC.
+	// Status indicates the current status of the VPC, PENDING or ACTIVE.
 	Status string `json:"status,omitempty"`
 
 	// CIDR is the actual CIDR block of the VPC.
 	CIDR string `json:"cidr,omitempty"`
+
+	// CIDRs are the effective CIDR blocks of the VPC, i.e. its CIDR followed
+	// by all its secondary CIDRs.
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// SecondaryCIDRs are the observed secondary CIDRs managed by the managed
+	// resource.
+	SecondaryCIDRs []string `json:"secondaryCidrs,omitempty"`
+
+	// Tags are the observed values of the tags managed by the managed
+	// resource.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// EnterpriseProjectID is the ID of the enterprise project of the VPC.
+	EnterpriseProjectID string `json:"enterpriseProjectId,omitempty"`
+}
+
+// A VPCImportSelector selects an existing VPC to import. A VPC matches if all
+// fields of the selector match.
+// +kubebuilder:validation:MinProperties=1
+type VPCImportSelector struct {
+	// Name of the VPC.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// CIDR of the VPC.
+	// +optional
+	CIDR *string `json:"cidr,omitempty"`
+
+	// Tags the VPC must carry.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // A VPCSpec defines the desired state of a VPC.
 type VPCSpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
 	ForProvider              VPCParameters `json:"forProvider"`
+
+	// ImportSelector imports an existing VPC instead of creating one. It is
+	// resolved once, while the managed resource has no external name, and the ID
+	// of the matching VPC is pinned in the external name. The import fails if no
+	// or more than one VPC matches.
+	// +optional
+	ImportSelector *VPCImportSelector `json:"importSelector,omitempty"`
 }
 
 // A VPCStatus represents the observed state of a VPC.
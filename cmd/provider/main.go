@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -29,7 +30,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/peertechde/provider-opentelekomcloud/apis"
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
 	opentelekomcloud "github.com/peertechde/provider-opentelekomcloud/internal/controller"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 	"github.com/peertechde/provider-opentelekomcloud/internal/version"
 )
 
@@ -51,6 +55,9 @@ func main() {
 		pollInterval = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").
 				Default("1m").
 				Duration()
+		providerConfigCheckInterval = app.Flag("provider-config-check-interval", "How often the credentials of every ProviderConfig are validated.").
+						Default("10m").
+						Duration()
 		pollStateMetricInterval = app.Flag("poll-state-metric", "State metric recording interval").
 					Default("5s").
 					Duration()
@@ -71,6 +78,28 @@ func main() {
 					Default("/var/run/changelogs/changelogs.sock").
 					Envar("CHANGELOGS_SOCKET_PATH").
 					String()
+
+		tracingExporter = app.Flag("tracing-exporter", "Where to export traces of reconciles and OTC API requests to.").
+				Default(tracing.ExporterNone).
+				Envar("TRACING_EXPORTER").
+				Enum(tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout)
+		tracingEndpoint = app.Flag("tracing-endpoint", "Host and port of the OTLP gRPC collector, e.g. localhost:4317.").
+				Envar("TRACING_ENDPOINT").
+				String()
+		tracingInsecure = app.Flag("tracing-insecure", "Connect to the OTLP collector without TLS.").
+				Default("false").
+				Envar("TRACING_INSECURE").
+				Bool()
+
+		wireLogging = app.Flag("wire-logging", "Log every request to Open Telekom Cloud including its body, with secrets redacted. ProviderConfigs can override it with spec.logging.wire.").
+				Default("false").
+				Envar("WIRE_LOGGING").
+				Bool()
+
+		planMode = app.Flag("plan", "Only record the changes the provider would make to external resources in the Planned condition and events. Managed resources can override it with the opentelekomcloud.crossplane.io/plan annotation.").
+				Default("false").
+				Envar("PLAN").
+				Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -87,6 +116,24 @@ func main() {
 		ctrl.SetLogger(zap.New(zap.WriteTo(io.Discard)))
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter: *tracingExporter,
+		Endpoint: *tracingEndpoint,
+		Insecure: *tracingInsecure,
+	})
+	kingpin.FatalIfError(err, "Cannot setup tracing")
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Info("Cannot flush traces", "error", err)
+		}
+	}()
+	if *planMode {
+		log.Info("Plan mode enabled, external resources are not changed")
+	}
+	if *tracingExporter != tracing.ExporterNone {
+		log.Info("Tracing enabled", "exporter", *tracingExporter)
+	}
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -124,8 +171,16 @@ func main() {
 	metricRecorder := managed.NewMRMetricRecorder()
 	stateMetrics := statemetrics.NewMRStateMetrics()
 
+	// All controllers share one session cache, so that every ProviderConfig
+	// authenticates only once.
+	sessions := clients.NewCache(mgr.GetClient(),
+		clients.WithLogger(log.WithValues("component", "wire")),
+		clients.WithWireLogging(*wireLogging),
+	)
+
 	metrics.Registry.MustRegister(metricRecorder)
 	metrics.Registry.MustRegister(stateMetrics)
+	metrics.Registry.MustRegister(sessions)
 
 	o := controller.Options{
 		Logger:                  log,
@@ -173,7 +228,12 @@ func main() {
 
 	kingpin.FatalIfError(customresourcesgate.Setup(mgr, o), "Cannot setup CRD gate controller")
 	kingpin.FatalIfError(
-		opentelekomcloud.SetupGated(mgr, o),
+		opentelekomcloud.SetupGated(mgr, options.Options{
+			Options:                     o,
+			Sessions:                    sessions,
+			ProviderConfigCheckInterval: *providerConfigCheckInterval,
+			Plan:                        *planMode,
+		}),
 		"Cannot setup OpenTelekomCloud controllers",
 	)
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
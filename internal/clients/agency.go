@@ -0,0 +1,118 @@
+package clients
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/identity/v3/credentials"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+const (
+	// DefaultAgencyDuration is how long temporary agency credentials are
+	// valid unless configured otherwise.
+	DefaultAgencyDuration = time.Hour
+
+	minAgencyDuration = 15 * time.Minute
+	maxAgencyDuration = 24 * time.Hour
+)
+
+// assumeRoleOpts requests temporary AK/SK credentials of an agency. The SDK's
+// CreateTemporaryOpts drops the domain once a duration is set, so the request
+// body is built here.
+type assumeRoleOpts struct {
+	DomainName string
+	AgencyName string
+	Duration   time.Duration
+}
+
+func (o assumeRoleOpts) ToTempCredentialCreateMap() (map[string]any, error) {
+	return map[string]any{
+		"auth": map[string]any{
+			"identity": map[string]any{
+				"methods": []string{"assume_role"},
+				"assume_role": map[string]any{
+					"domain_name":      o.DomainName,
+					"agency_name":      o.AgencyName,
+					"duration_seconds": int(o.Duration.Seconds()),
+				},
+			},
+		},
+	}, nil
+}
+
+// agencyDuration returns the validated duration of the supplied agency.
+func agencyDuration(agency *v1alpha1.AssumeAgency) (time.Duration, error) {
+	if agency.Duration == nil {
+		return DefaultAgencyDuration, nil
+	}
+
+	d := agency.Duration.Duration
+	if d < minAgencyDuration || d > maxAgencyDuration {
+		return 0, errors.Errorf("agency duration %s must be between %s and %s", d, minAgencyDuration, maxAgencyDuration)
+	}
+	return d, nil
+}
+
+// assumeAgency authenticates with the base credentials and exchanges them
+// for the temporary credentials of the configured agency.
+func assumeAgency(
+	endpoint string,
+	spec v1alpha1.ProviderConfigSpec,
+	creds *Credentials,
+	hc http.Client,
+) (*Credentials, error) {
+	// The base credentials belong to another domain than the project
+	// resources are managed in, so they are not scoped to the project.
+	base := spec
+	base.ProjectID = ""
+
+	providerClient, err := authenticate(authOptions(endpoint, authMethod(spec), base, creds), hc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot authenticate with the base credentials")
+	}
+
+	identity, err := openstack.NewIdentityV3(providerClient, golangsdk.EndpointOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create identity client")
+	}
+
+	return exchangeAgencyCredentials(identity, spec.AssumeAgency)
+}
+
+// exchangeAgencyCredentials requests the temporary credentials of the
+// supplied agency.
+func exchangeAgencyCredentials(
+	identity *golangsdk.ServiceClient,
+	agency *v1alpha1.AssumeAgency,
+) (*Credentials, error) {
+	duration, err := agencyDuration(agency)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := credentials.CreateTemporary(identity, assumeRoleOpts{
+		DomainName: agency.DomainName,
+		AgencyName: agency.AgencyName,
+		Duration:   duration,
+	}).Extract()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot assume agency %s of domain %s", agency.AgencyName, agency.DomainName)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339Nano, tmp.ExpiresAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse expiry of the agency credentials")
+	}
+
+	return &Credentials{
+		AccessKey:     tmp.AccessKey,
+		SecretKey:     tmp.SecretKey,
+		SecurityToken: tmp.SecurityToken,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+	fake "github.com/opentelekomcloud/gophertelekomcloud/testhelper/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+func TestExchangeAgencyCredentials(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type args struct {
+		handler http.HandlerFunc
+		agency  *v1alpha1.AssumeAgency
+	}
+
+	type want struct {
+		creds *Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "Should exchange the base credentials for temporary agency credentials",
+			args: args{
+				handler: func(w http.ResponseWriter, r *http.Request) {
+					testhelper.TestMethod(t, r, "POST")
+					testhelper.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+					testhelper.TestJSONRequest(t, r, `
+						{
+							"auth": {
+								"identity": {
+									"methods": ["assume_role"],
+									"assume_role": {
+										"domain_name": "tenant",
+										"agency_name": "automation",
+										"duration_seconds": 1800
+									}
+								}
+							}
+						}
+					`)
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `
+						{
+							"credential": {
+								"access": "temporary-ak",
+								"secret": "temporary-sk",
+								"securitytoken": "token",
+								"expires_at": %q
+							}
+						}
+					`, expiresAt.Format("2006-01-02T15:04:05.000000Z"))
+				},
+				agency: &v1alpha1.AssumeAgency{
+					DomainName: "tenant",
+					AgencyName: "automation",
+					Duration:   &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+			want: want{
+				creds: &Credentials{
+					AccessKey:     "temporary-ak",
+					SecretKey:     "temporary-sk",
+					SecurityToken: "token",
+					ExpiresAt:     expiresAt,
+				},
+			},
+		},
+		"Forbidden": {
+			reason: "Should fail when the agency cannot be assumed",
+			args: args{
+				handler: func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+				},
+				agency: &v1alpha1.AssumeAgency{DomainName: "tenant", AgencyName: "automation"},
+			},
+			want: want{
+				err: fmt.Errorf("cannot assume agency automation of domain tenant"),
+			},
+		},
+		"DurationTooShort": {
+			reason: "Should reject durations below the IAM minimum",
+			args: args{
+				agency: &v1alpha1.AssumeAgency{
+					DomainName: "tenant",
+					AgencyName: "automation",
+					Duration:   &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("agency duration 1m0s must be between 15m0s and 24h0m0s"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			handler := tc.args.handler
+			if handler == nil {
+				handler = func(w http.ResponseWriter, _ *http.Request) {
+					t.Errorf("unexpected request to the identity service")
+				}
+			}
+			testhelper.Mux.HandleFunc("/v3.0/OS-CREDENTIAL/securitytokens", handler)
+
+			identity := fake.ServiceClient()
+			identity.Endpoint += "v3/"
+
+			got, err := exchangeAgencyCredentials(identity, tc.args.agency)
+
+			if tc.want.err != nil {
+				if err == nil {
+					t.Errorf("\n%s\nexchangeAgencyCredentials(...): -want error, +got nil\n", tc.reason)
+				} else if !strings.Contains(err.Error(), tc.want.err.Error()) {
+					t.Errorf("\n%s\nexchangeAgencyCredentials(...): -want error containing %q, +got %q\n", tc.reason, tc.want.err.Error(), err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("\n%s\nexchangeAgencyCredentials(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.creds, got); diff != "" {
+				t.Errorf("\n%s\nexchangeAgencyCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
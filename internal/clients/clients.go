@@ -4,32 +4,25 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"golang.org/x/sync/singleflight"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
-const (
-	// DefaultIdentityEndpoint is the default OTC identity endpoint.
-	DefaultIdentityEndpoint = "https://iam.eu-de.otc.t-systems.com/v3"
-)
-
-// Credentials represents AK/SK credentials.
-type Credentials struct {
-	AccessKey string `json:"accessKey"`
-	SecretKey string `json:"secretKey"`
-}
-
+// Client creates service clients for an authenticated session. The service
+// clients honour the endpoint overrides of the ProviderConfig.
 type Client struct {
 	ProviderClient *golangsdk.ProviderClient
 	Region         string
@@ -49,6 +42,13 @@ func (c *Client) NewNetworkV2Client() (*golangsdk.ServiceClient, error) {
 	})
 }
 
+// NewNatV2Client creates a client for NAT V2 service.
+func (c *Client) NewNatV2Client() (*golangsdk.ServiceClient, error) {
+	return openstack.NewNatV2(c.ProviderClient, golangsdk.EndpointOpts{
+		Region: c.Region,
+	})
+}
+
 // NewVPCV3Client creates a client for VPC V3 service.
 func (c *Client) NewVPCV3Client() (*golangsdk.ServiceClient, error) {
 	return openstack.NewVpcV3(c.ProviderClient, golangsdk.EndpointOpts{
@@ -56,6 +56,46 @@ func (c *Client) NewVPCV3Client() (*golangsdk.ServiceClient, error) {
 	})
 }
 
+// NewEPSClient creates a client for the Enterprise Project Service, whose
+// resource paths start with its API version. Override the eps endpoint if the
+// service catalog of the region lacks it.
+func (c *Client) NewEPSClient() (*golangsdk.ServiceClient, error) {
+	eo := golangsdk.EndpointOpts{Region: c.Region}
+	eo.ApplyDefaults("eps")
+	endpoint, err := c.ProviderClient.EndpointLocator(eo)
+	if err != nil {
+		return nil, err
+	}
+	return &golangsdk.ServiceClient{
+		ProviderClient: c.ProviderClient,
+		Endpoint:       endpoint,
+		ResourceBase:   endpoint + "v1.0/",
+		Type:           "eps",
+	}, nil
+}
+
+// CheckProject checks that the project of the client is reachable in its
+// region by listing a single VPC.
+func (c *Client) CheckProject() error {
+	sc, err := c.NewNetworkV1Client()
+	if err != nil {
+		return errors.Wrapf(err, "cannot find the network endpoint of region %s", c.Region)
+	}
+
+	_, err = sc.Get(sc.ServiceURL(sc.ProjectID, "vpcs")+"?limit=1", nil, nil)
+	return errors.Wrapf(err, "cannot reach project %s in region %s", sc.ProjectID, c.Region)
+}
+
+// ProviderConfigKey returns the cache key of a namespaced ProviderConfig.
+func ProviderConfigKey(namespace, name string) string {
+	return fmt.Sprintf("ProviderConfig/%s/%s", namespace, name)
+}
+
+// ClusterProviderConfigKey returns the cache key of a ClusterProviderConfig.
+func ClusterProviderConfigKey(name string) string {
+	return fmt.Sprintf("ClusterProviderConfig/%s", name)
+}
+
 // session holds an active connection and metadata.
 type session struct {
 	client    *golangsdk.ProviderClient
@@ -63,134 +103,279 @@ type session struct {
 	hash      string
 }
 
-// Cache manages the lifecycle of OTC connections to prevent rate limiting.
+// Cache manages the lifecycle of OTC connections to prevent rate limiting. A
+// single Cache is shared by all controllers of the provider, so that every
+// ProviderConfig authenticates only once.
 type Cache struct {
 	mu       sync.RWMutex
-	sessions map[string]*session // Key: ProviderConfig Name
+	sessions map[string]*session // Key: ProviderConfig kind, namespace and name
 	client   client.Client
+
+	// metadata fetches temporary credentials for the InjectedIdentity source.
+	metadata *metadataClient
+
+	// logins deduplicates concurrent authentications for the same key.
+	logins  singleflight.Group
+	metrics *cacheMetrics
+	api     *apiMetrics
+
+	log logging.Logger
+
+	// wireLogging is whether the requests of ProviderConfigs that do not
+	// configure wire logging themselves are logged.
+	wireLogging bool
 }
 
-// NewCache creates a new cache. Call this in your Controller Setup.
-func NewCache(kube client.Client) *Cache {
-	return &Cache{
+// A CacheOption configures a Cache.
+type CacheOption func(*Cache)
+
+// WithLogger configures the logger requests are logged to.
+func WithLogger(l logging.Logger) CacheOption {
+	return func(c *Cache) {
+		c.log = l
+	}
+}
+
+// WithWireLogging configures whether the requests of all ProviderConfigs are
+// logged by default. ProviderConfigs can override it with spec.logging.wire.
+func WithWireLogging(enabled bool) CacheOption {
+	return func(c *Cache) {
+		c.wireLogging = enabled
+	}
+}
+
+// NewCache creates a new cache. Create it once in main and share it between
+// all controllers.
+func NewCache(kube client.Client, opts ...CacheOption) *Cache {
+	c := &Cache{
 		sessions: make(map[string]*session),
 		client:   kube,
+		metadata: newMetadataClient(DefaultMetadataEndpoint),
+		metrics:  newCacheMetrics(),
+		api:      newAPIMetrics(),
+		log:      logging.NewNopLogger(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// wireLog returns whether the requests of the supplied ProviderConfig are
+// logged.
+func (c *Cache) wireLog(spec v1alpha1.ProviderConfigSpec) bool {
+	if spec.Logging != nil && spec.Logging.Wire != nil {
+		return *spec.Logging.Wire
 	}
+	return c.wireLogging
 }
 
-// GetClient returns a cached client or creates a new one.
+// Evict removes the session of the supplied key from the cache.
+func (c *Cache) Evict(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.sessions[key]; ok {
+		delete(c.sessions, key)
+		c.metrics.evictions.Inc()
+	}
+	c.api.forget(key)
+}
+
+// GetClient returns a cached client or creates a new one. The requests of
+// the client belong to the trace and the request log of the supplied context.
 func (c *Cache) GetClient(
 	ctx context.Context,
 	key string,
 	spec v1alpha1.ProviderConfigSpec,
-) (*Client, error) {
-	// Resolve credentials (AK/SK) from secret
-	creds, err := extractCredentials(ctx, c.client, spec)
+) (_ *Client, err error) {
+	ctx, span := tracing.Start(ctx, "GetClient", attrProviderConfig.String(key))
+	defer func() { tracing.End(span, err) }()
+
+	// Resolve credentials from the configured source
+	creds, err := extractCredentials(ctx, c.client, c.metadata, spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot extract credentials")
 	}
 
+	tr, err := extractTransport(ctx, c.client, spec.Transport)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot extract transport settings")
+	}
+
 	// If the secret changes (new key) or spec changes.
-	configHash := calculateHash(spec, creds)
+	configHash := calculateHash(spec, creds, tr, c.wireLog(spec))
 
 	// Check the cache
 	c.mu.RLock()
 	cached, ok := c.sessions[key]
 	c.mu.RUnlock()
 
-	if ok && cached.hash == configHash && time.Now().Add(5*time.Minute).Before(cached.expiresAt) {
+	hit := ok && cached.hash == configHash && time.Now().Add(5*time.Minute).Before(cached.expiresAt)
+	span.SetAttributes(attrCacheHit.Bool(hit))
+	if hit {
+		c.metrics.hits.Inc()
 		return &Client{
-			ProviderClient: cached.client,
+			ProviderClient: bind(ctx, cached.client),
 			Region:         spec.Region,
 		}, nil
 	}
+	c.metrics.misses.Inc()
 
-	// Create a new provider client
-	endpoint := DefaultIdentityEndpoint
-	if spec.IdentityEndpoint != nil && *spec.IdentityEndpoint != "" {
-		endpoint = *spec.IdentityEndpoint
-	}
-
-	authOpts := golangsdk.AKSKAuthOptions{
-		IdentityEndpoint: endpoint,
-		AccessKey:        creds.AccessKey,
-		SecretKey:        creds.SecretKey,
-		ProjectId:        spec.ProjectID,
-		Region:           spec.Region,
-	}
-
-	providerClient, err := openstack.AuthenticatedClient(authOpts)
+	// Parallel reconciles of resources sharing a ProviderConfig must not start
+	// parallel logins, so only the first caller authenticates and the others
+	// wait for its result.
+	v, err, _ := c.logins.Do(key+"/"+configHash, func() (any, error) {
+		return c.login(ctx, key, configHash, spec, creds, tr)
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot authenticate with Open Telekom Cloud")
-	}
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	// Cache for 23 hours (tokens usually last 24h)
-	c.sessions[key] = &session{
-		client:    providerClient,
-		expiresAt: time.Now().Add(23 * time.Hour),
-		hash:      configHash,
+		return nil, err
 	}
 
 	return &Client{
-		ProviderClient: providerClient,
+		ProviderClient: bind(ctx, v.(*golangsdk.ProviderClient)),
 		Region:         spec.Region,
 	}, nil
 }
 
-// extractCredentials extracts AK/SK credentials from the configured source.
-func extractCredentials(
+// login authenticates against the identity endpoint and stores the resulting
+// session under the supplied key.
+func (c *Cache) login(
 	ctx context.Context,
-	kube client.Client,
+	key string,
+	configHash string,
 	spec v1alpha1.ProviderConfigSpec,
-) (*Credentials, error) {
-	if spec.Credentials.Source != xpv1.CredentialsSourceSecret {
-		return nil, errors.Errorf("unsupported credentials source: %s", spec.Credentials.Source)
+	creds *Credentials,
+	tr *transport,
+) (_ *golangsdk.ProviderClient, err error) {
+	ctx, span := tracing.Start(ctx, "Authenticate", attrProviderConfig.String(key))
+	defer func() { tracing.End(span, err) }()
+
+	endpoint := identityEndpoint(spec)
+
+	hc, err := tr.httpClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot configure HTTP transport")
 	}
+	if c.wireLog(spec) {
+		hc.Transport = newWireLogTransport(hc.Transport, c.log.WithValues("providerconfig", key))
+	}
+	// Every attempt of a retried request is recorded with its own status.
+	hc.Transport = newInstrumentTransport(hc.Transport, c.api, key, endpoint, spec.Endpoints)
+	hc.Transport = newRetryTransport(hc.Transport, spec.Retry, c.api)
+
+	// The requests of the authentication belong to its span. The login is
+	// shared by all waiting callers, so it is not cancelled with the context
+	// of the first one.
+	authHC := hc
+	authHC.Transport = &contextTransport{ctx: context.WithoutCancel(ctx), next: hc.Transport}
 
-	ref := spec.Credentials.SecretRef
-	if ref == nil {
-		return nil, errors.New("secretRef is required")
+	c.metrics.logins.Inc()
+
+	method := authMethod(spec)
+	if spec.AssumeAgency != nil {
+		agencyCreds, err := assumeAgency(endpoint, spec, creds, authHC)
+		if err != nil {
+			c.metrics.loginErrors.Inc()
+			return nil, err
+		}
+		method, creds = v1alpha1.AuthMethodAKSK, agencyCreds
 	}
 
-	// Use Crossplane helper to fetch secret data
-	data, err := resource.CommonCredentialExtractor(
-		ctx,
-		spec.Credentials.Source,
-		kube,
-		spec.Credentials.CommonCredentialSelectors,
-	)
+	providerClient, err := authenticate(authOptions(endpoint, method, spec, creds), authHC)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot get credentials from secret")
+		c.metrics.loginErrors.Inc()
+		return nil, errors.Wrap(err, "cannot authenticate with Open Telekom Cloud")
 	}
+	providerClient.HTTPClient = hc
+	overrideEndpoints(providerClient, spec.Endpoints)
+
+	// Throttled requests are retried by the retry transport. The SDK would
+	// otherwise block for a minute per 429 response.
+	providerClient.MaxBackoffRetries = pointer.To(0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// Unmarshal JSON into AK/SK struct
-	creds := &Credentials{}
-	if err := json.Unmarshal(data, creds); err != nil {
-		return nil, errors.Wrap(
-			err,
-			"cannot unmarshal credentials JSON, expect keys: accessKey, secretKey",
-		)
+	// Cache for 23 hours (tokens usually last 24h), unless the credentials
+	// themselves are temporary and expire earlier. Such sessions are
+	// renewed shortly before their credentials expire.
+	expiresAt := time.Now().Add(23 * time.Hour)
+	if !creds.ExpiresAt.IsZero() && creds.ExpiresAt.Before(expiresAt) {
+		expiresAt = creds.ExpiresAt
 	}
 
-	if creds.AccessKey == "" || creds.SecretKey == "" {
-		return nil, errors.New("accessKey and secretKey are required in credentials secret")
+	c.sessions[key] = &session{
+		client:    providerClient,
+		expiresAt: expiresAt,
+		hash:      configHash,
 	}
 
-	return creds, nil
+	return providerClient, nil
 }
 
-func calculateHash(spec v1alpha1.ProviderConfigSpec, creds *Credentials) string {
+// authOptions returns the SDK authentication options of the supplied
+// authentication method.
+func authOptions(
+	endpoint string,
+	method v1alpha1.AuthMethod,
+	spec v1alpha1.ProviderConfigSpec,
+	creds *Credentials,
+) golangsdk.AuthOptionsProvider {
+	switch method {
+	case v1alpha1.AuthMethodPassword:
+		return golangsdk.AuthOptions{
+			IdentityEndpoint: endpoint,
+			Username:         creds.Username,
+			Password:         creds.Password,
+			DomainName:       spec.DomainName,
+			TenantID:         spec.ProjectID,
+			AllowReauth:      true,
+		}
+	case v1alpha1.AuthMethodToken:
+		// A pre-issued token cannot be renewed. Once it expires the
+		// credentials have to be updated with a new one.
+		return golangsdk.AuthOptions{
+			IdentityEndpoint: endpoint,
+			TokenID:          creds.Token,
+			TenantID:         spec.ProjectID,
+		}
+	case v1alpha1.AuthMethodAKSK:
+		fallthrough
+	default:
+		return golangsdk.AKSKAuthOptions{
+			IdentityEndpoint: endpoint,
+			AccessKey:        creds.AccessKey,
+			SecretKey:        creds.SecretKey,
+			SecurityToken:    creds.SecurityToken,
+			ProjectId:        spec.ProjectID,
+			Region:           spec.Region,
+		}
+	}
+}
+
+func calculateHash(spec v1alpha1.ProviderConfigSpec, creds *Credentials, tr *transport, wireLog bool) string {
 	// Concatenate fields that affect authentication identity
-	s := fmt.Sprintf("%s|%s|%s|%s|%s",
+	s := fmt.Sprintf("%s|%s|%s|%s|%t|%s|%s|%s|%s|%s|%s|%s|%s|%s|%s",
+		identityEndpoint(spec),
+		endpointsHash(spec.Endpoints),
+		tr.hash(),
+		newRetryPolicy(spec.Retry),
+		wireLog,
 		spec.DomainName,
 		spec.ProjectID,
 		spec.Region,
+		authMethod(spec),
 		creds.AccessKey,
 		creds.SecretKey,
+		creds.SecurityToken,
+		creds.Username,
+		creds.Password,
+		creds.Token,
 	)
+	if a := spec.AssumeAgency; a != nil {
+		s += fmt.Sprintf("|%s|%s|%v", a.DomainName, a.AgencyName, a.Duration)
+	}
 	h := sha256.Sum256([]byte(s))
 	return hex.EncodeToString(h[:])
 }
@@ -0,0 +1,150 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+// identityServer returns a fake identity service that issues a token for
+// every login and counts the logins.
+func identityServer(t *testing.T, logins *atomic.Int32, delay time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/auth/tokens" {
+			t.Errorf("identity request: want POST /v3/auth/tokens, got %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logins.Add(1)
+		time.Sleep(delay)
+		w.Header().Add("Content-Type", "application/json")
+		w.Header().Add("X-Subject-Token", "token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": %q,
+					"catalog": [],
+					"project": {"id": "project", "name": "eu-de", "domain": {"id": "domain"}},
+					"user": {"id": "user", "domain": {"id": "domain"}}
+				}
+			}
+		`, time.Now().Add(24*time.Hour).UTC().Format("2006-01-02T15:04:05.000000Z"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func passwordSpec(endpoint, domain string) v1alpha1.ProviderConfigSpec {
+	return v1alpha1.ProviderConfigSpec{
+		IdentityEndpoint: pointer.To(endpoint + "/v3"),
+		Region:           "eu-de",
+		DomainName:       domain,
+		ProjectID:        "project",
+		AuthMethod:       v1alpha1.AuthMethodPassword,
+		Credentials:      secretCredentials(),
+	}
+}
+
+func TestCacheGetClient(t *testing.T) {
+	// call is a call of GetClient with the spec of the supplied domain,
+	// optionally after evicting the cached session.
+	type call struct {
+		domain string
+		evict  bool
+	}
+
+	type want struct {
+		logins    int32
+		hits      float64
+		misses    float64
+		evictions float64
+	}
+
+	cases := map[string]struct {
+		reason string
+		calls  []call
+		want   want
+	}{
+		"Hit": {
+			reason: "Should reuse the cached session of an unchanged ProviderConfig",
+			calls:  []call{{domain: "a"}, {domain: "a"}, {domain: "a"}},
+			want:   want{logins: 1, hits: 2, misses: 1},
+		},
+		"ConfigChanged": {
+			reason: "Should log in again once the config hash of a ProviderConfig changes",
+			calls:  []call{{domain: "a"}, {domain: "b"}, {domain: "b"}},
+			want:   want{logins: 2, hits: 1, misses: 2},
+		},
+		"Evicted": {
+			reason: "Should log in again once the session of a ProviderConfig is evicted",
+			calls:  []call{{domain: "a"}, {domain: "a", evict: true}},
+			want:   want{logins: 2, misses: 2, evictions: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var logins atomic.Int32
+			srv := identityServer(t, &logins, 0)
+
+			c := NewCache(secretClient(passwordJSON))
+			key := ProviderConfigKey("default", "pc")
+			for _, cl := range tc.calls {
+				if cl.evict {
+					c.Evict(key)
+				}
+				if _, err := c.GetClient(context.Background(), key, passwordSpec(srv.URL, cl.domain)); err != nil {
+					t.Fatalf("\n%s\nc.GetClient(...): %v", tc.reason, err)
+				}
+			}
+
+			got := want{
+				logins:    logins.Load(),
+				hits:      testutil.ToFloat64(c.metrics.hits),
+				misses:    testutil.ToFloat64(c.metrics.misses),
+				evictions: testutil.ToFloat64(c.metrics.evictions),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nc.GetClient(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCacheGetClientConcurrent(t *testing.T) {
+	var logins atomic.Int32
+	srv := identityServer(t, &logins, 100*time.Millisecond)
+
+	c := NewCache(secretClient(passwordJSON))
+	key := ProviderConfigKey("default", "pc")
+	spec := passwordSpec(srv.URL, "a")
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetClient(context.Background(), key, spec); err != nil {
+				t.Errorf("c.GetClient(...): %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := logins.Load(); got != 1 {
+		t.Errorf("concurrent c.GetClient(...): want 1 login, got %d", got)
+	}
+}
@@ -0,0 +1,202 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+const (
+	// DefaultMetadataEndpoint is the ECS metadata endpoint serving the
+	// temporary credentials of the agency bound to the instance.
+	DefaultMetadataEndpoint = "http://169.254.169.254/openstack/latest/securitykey"
+
+	// metadataRefreshMargin is how long before their expiry temporary
+	// credentials are fetched again.
+	metadataRefreshMargin = 5 * time.Minute
+)
+
+// Credentials represents the credentials of one of the supported
+// authentication methods. Only the keys of the configured method are set.
+type Credentials struct {
+	// AccessKey and SecretKey are used by the AKSK method.
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// Username and Password are used by the Password method.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	// Token is used by the Token method.
+	Token string `json:"token,omitempty"`
+
+	// SecurityToken is set for temporary AK/SK credentials only.
+	SecurityToken string `json:"securityToken,omitempty"`
+
+	// ExpiresAt is set for temporary credentials only.
+	ExpiresAt time.Time `json:"-"`
+}
+
+// authMethod returns the configured authentication method of the supplied
+// spec, defaulting to AK/SK.
+func authMethod(spec v1alpha1.ProviderConfigSpec) v1alpha1.AuthMethod {
+	if spec.AuthMethod == "" {
+		return v1alpha1.AuthMethodAKSK
+	}
+	return spec.AuthMethod
+}
+
+// extractCredentials extracts the credentials of the configured
+// authentication method from the configured source.
+func extractCredentials(
+	ctx context.Context,
+	kube client.Client,
+	metadata *metadataClient,
+	spec v1alpha1.ProviderConfigSpec,
+) (*Credentials, error) {
+	switch spec.Credentials.Source { //nolint:exhaustive // None is rejected below.
+	case xpv1.CredentialsSourceInjectedIdentity:
+		if authMethod(spec) != v1alpha1.AuthMethodAKSK {
+			return nil, errors.Errorf("credentials source %s requires auth method %s", spec.Credentials.Source, v1alpha1.AuthMethodAKSK)
+		}
+		creds, err := metadata.credentials(ctx)
+		return creds, errors.Wrap(err, "cannot get credentials from the ECS metadata service")
+	case xpv1.CredentialsSourceSecret:
+		if spec.Credentials.SecretRef == nil {
+			return nil, errors.New("secretRef is required")
+		}
+	case xpv1.CredentialsSourceEnvironment:
+		if spec.Credentials.Env == nil {
+			return nil, errors.New("env is required")
+		}
+	case xpv1.CredentialsSourceFilesystem:
+		if spec.Credentials.Fs == nil {
+			return nil, errors.New("fs is required")
+		}
+	default:
+		return nil, errors.Errorf("unsupported credentials source: %s", spec.Credentials.Source)
+	}
+
+	// Use Crossplane helper to fetch the secret, environment variable or file
+	data, err := resource.CommonCredentialExtractor(
+		ctx,
+		spec.Credentials.Source,
+		kube,
+		spec.Credentials.CommonCredentialSelectors,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials from source %s", spec.Credentials.Source)
+	}
+
+	creds := &Credentials{}
+	if err := json.Unmarshal(data, creds); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal credentials JSON")
+	}
+
+	if err := validateCredentials(authMethod(spec), creds); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}
+
+// validateCredentials checks that the credentials contain the keys required
+// by the supplied authentication method.
+func validateCredentials(method v1alpha1.AuthMethod, creds *Credentials) error {
+	switch method {
+	case v1alpha1.AuthMethodAKSK:
+		if creds.AccessKey == "" || creds.SecretKey == "" {
+			return errors.New("accessKey and secretKey are required in credentials")
+		}
+	case v1alpha1.AuthMethodPassword:
+		if creds.Username == "" || creds.Password == "" {
+			return errors.New("username and password are required in credentials")
+		}
+	case v1alpha1.AuthMethodToken:
+		if creds.Token == "" {
+			return errors.New("token is required in credentials")
+		}
+	default:
+		return errors.Errorf("unsupported auth method: %s", method)
+	}
+	return nil
+}
+
+// securityKey is the response of the ECS metadata security key endpoint.
+type securityKey struct {
+	Credential struct {
+		Access        string    `json:"access"`
+		Secret        string    `json:"secret"`
+		SecurityToken string    `json:"securitytoken"`
+		ExpiresAt     time.Time `json:"expires_at"`
+	} `json:"credential"`
+}
+
+// metadataClient fetches the temporary credentials of the agency bound to the
+// ECS instance the provider runs on. The credentials are reused until shortly
+// before they expire.
+type metadataClient struct {
+	endpoint string
+	http     *http.Client
+
+	mu     sync.Mutex
+	cached *Credentials
+}
+
+func newMetadataClient(endpoint string) *metadataClient {
+	return &metadataClient{
+		endpoint: endpoint,
+		http:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (m *metadataClient) credentials(ctx context.Context) (*Credentials, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cached != nil && time.Now().Add(metadataRefreshMargin).Before(m.cached.ExpiresAt) {
+		return m.cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	key := &securityKey{}
+	if err := json.NewDecoder(resp.Body).Decode(key); err != nil {
+		return nil, errors.Wrap(err, "cannot decode security key")
+	}
+
+	if key.Credential.Access == "" || key.Credential.Secret == "" {
+		return nil, errors.New("no agency is bound to the ECS instance")
+	}
+
+	m.cached = &Credentials{
+		AccessKey:     key.Credential.Access,
+		SecretKey:     key.Credential.Secret,
+		SecurityToken: key.Credential.SecurityToken,
+		ExpiresAt:     key.Credential.ExpiresAt,
+	}
+
+	return m.cached, nil
+}
@@ -0,0 +1,383 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+const (
+	akskJSON     = `{"accessKey": "ak", "secretKey": "sk"}`
+	passwordJSON = `{"username": "user", "password": "secret"}`
+	tokenJSON    = `{"token": "issued-token"}`
+)
+
+func secretClient(data string) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"credentials": []byte(data)}
+			return nil
+		}),
+	}
+}
+
+func secretCredentials() v1alpha1.ProviderCredentials {
+	return v1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "default"},
+				Key:             "credentials",
+			},
+		},
+	}
+}
+
+func metadataHandler(t *testing.T, calls *int, expiresAt time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if r.Method != http.MethodGet {
+			t.Errorf("metadata request: want method GET, got %s", r.Method)
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"credential": {
+					"access": "temporary-ak",
+					"secret": "temporary-sk",
+					"securitytoken": "token",
+					"expires_at": %q
+				}
+			}
+		`, expiresAt.Format(time.RFC3339Nano))
+	}
+}
+
+func TestExtractCredentials(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.json")
+	if err := os.WriteFile(path, []byte(akskJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("OTC_TEST_CREDENTIALS", akskJSON)
+
+	type args struct {
+		kube    client.Client
+		handler http.HandlerFunc
+		spec    v1alpha1.ProviderConfigSpec
+	}
+
+	type want struct {
+		creds *Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Secret": {
+			reason: "Should read AK/SK from the referenced secret key",
+			args: args{
+				kube: secretClient(akskJSON),
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				creds: &Credentials{AccessKey: "ak", SecretKey: "sk"},
+			},
+		},
+		"SecretMissingKeys": {
+			reason: "Should fail when the AK/SK keys are missing",
+			args: args{
+				kube: secretClient(passwordJSON),
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod:  v1alpha1.AuthMethodAKSK,
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				err: fmt.Errorf("accessKey and secretKey are required in credentials"),
+			},
+		},
+		"Password": {
+			reason: "Should read username and password for the Password method",
+			args: args{
+				kube: secretClient(passwordJSON),
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod:  v1alpha1.AuthMethodPassword,
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				creds: &Credentials{Username: "user", Password: "secret"},
+			},
+		},
+		"PasswordMissingKeys": {
+			reason: "Should fail when the Password method has no password",
+			args: args{
+				kube: secretClient(`{"username": "user"}`),
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod:  v1alpha1.AuthMethodPassword,
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				err: fmt.Errorf("username and password are required in credentials"),
+			},
+		},
+		"Token": {
+			reason: "Should read the token for the Token method",
+			args: args{
+				kube: secretClient(tokenJSON),
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod:  v1alpha1.AuthMethodToken,
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				creds: &Credentials{Token: "issued-token"},
+			},
+		},
+		"TokenMissingKey": {
+			reason: "Should fail when the Token method has no token",
+			args: args{
+				kube: secretClient(akskJSON),
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod:  v1alpha1.AuthMethodToken,
+					Credentials: secretCredentials(),
+				},
+			},
+			want: want{
+				err: fmt.Errorf("token is required in credentials"),
+			},
+		},
+		"SecretMissingRef": {
+			reason: "Should fail when the Secret source has no secretRef",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("secretRef is required"),
+			},
+		},
+		"Environment": {
+			reason: "Should read AK/SK from the referenced environment variable",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceEnvironment,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+							Env: &xpv1.EnvSelector{Name: "OTC_TEST_CREDENTIALS"},
+						},
+					},
+				},
+			},
+			want: want{
+				creds: &Credentials{AccessKey: "ak", SecretKey: "sk"},
+			},
+		},
+		"EnvironmentEmpty": {
+			reason: "Should fail when the referenced environment variable is not set",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceEnvironment,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+							Env: &xpv1.EnvSelector{Name: "OTC_TEST_CREDENTIALS_UNSET"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("cannot unmarshal credentials JSON"),
+			},
+		},
+		"Filesystem": {
+			reason: "Should read AK/SK from the referenced file",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceFilesystem,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+							Fs: &xpv1.FsSelector{Path: path},
+						},
+					},
+				},
+			},
+			want: want{
+				creds: &Credentials{AccessKey: "ak", SecretKey: "sk"},
+			},
+		},
+		"FilesystemMissing": {
+			reason: "Should fail when the referenced file does not exist",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceFilesystem,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+							Fs: &xpv1.FsSelector{Path: filepath.Join(dir, "missing.json")},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("cannot get credentials from source Filesystem"),
+			},
+		},
+		"InjectedIdentity": {
+			reason: "Should fetch temporary credentials from the ECS metadata service",
+			args: args{
+				handler: metadataHandler(t, new(int), expiresAt),
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceInjectedIdentity,
+					},
+				},
+			},
+			want: want{
+				creds: &Credentials{
+					AccessKey:     "temporary-ak",
+					SecretKey:     "temporary-sk",
+					SecurityToken: "token",
+					ExpiresAt:     expiresAt,
+				},
+			},
+		},
+		"InjectedIdentityNoAgency": {
+			reason: "Should fail when the metadata service returns an error",
+			args: args{
+				handler: func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				},
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceInjectedIdentity,
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("cannot get credentials from the ECS metadata service"),
+			},
+		},
+		"InjectedIdentityPassword": {
+			reason: "Should reject the InjectedIdentity source for methods other than AKSK",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					AuthMethod: v1alpha1.AuthMethodPassword,
+					Credentials: v1alpha1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceInjectedIdentity,
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("credentials source InjectedIdentity requires auth method AKSK"),
+			},
+		},
+		"None": {
+			reason: "Should reject the None source",
+			args: args{
+				spec: v1alpha1.ProviderConfigSpec{
+					Credentials: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("unsupported credentials source: None"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			handler := tc.args.handler
+			if handler == nil {
+				handler = func(w http.ResponseWriter, _ *http.Request) {
+					t.Errorf("unexpected request to the metadata service")
+				}
+			}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			got, err := extractCredentials(
+				context.Background(),
+				tc.args.kube,
+				newMetadataClient(srv.URL),
+				tc.args.spec,
+			)
+
+			if tc.want.err != nil {
+				if err == nil {
+					t.Errorf("\n%s\nextractCredentials(...): -want error, +got nil\n", tc.reason)
+				} else if !strings.Contains(err.Error(), tc.want.err.Error()) {
+					t.Errorf("\n%s\nextractCredentials(...): -want error containing %q, +got %q\n", tc.reason, tc.want.err.Error(), err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("\n%s\nextractCredentials(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.creds, got); diff != "" {
+				t.Errorf("\n%s\nextractCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMetadataClientReusesCredentials(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		expiresAt time.Time
+		want      int
+	}{
+		"Valid": {
+			reason:    "Should reuse credentials that are still valid",
+			expiresAt: time.Now().Add(time.Hour),
+			want:      1,
+		},
+		"AboutToExpire": {
+			reason:    "Should refresh credentials shortly before they expire",
+			expiresAt: time.Now().Add(time.Minute),
+			want:      2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(metadataHandler(t, &calls, tc.expiresAt))
+			defer srv.Close()
+
+			m := newMetadataClient(srv.URL)
+			for range 2 {
+				if _, err := m.credentials(context.Background()); err != nil {
+					t.Fatalf("\n%s\nm.credentials(...): -want nil, +got error %v\n", tc.reason, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, calls); diff != "" {
+				t.Errorf("\n%s\nmetadata requests: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
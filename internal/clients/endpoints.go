@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+// ServiceIdentity is the endpoints key of the identity service.
+const ServiceIdentity = "iam"
+
+// identityEndpoints are the identity endpoints of regions that do not follow
+// the iam.<region>.otc.t-systems.com scheme.
+var identityEndpoints = map[string]string{
+	"eu-ch2": "https://iam-pub.eu-ch2.sc.otc.t-systems.com/v3",
+}
+
+// RegionIdentityEndpoint returns the public identity endpoint of the supplied
+// region.
+func RegionIdentityEndpoint(region string) string {
+	if e, ok := identityEndpoints[region]; ok {
+		return e
+	}
+	return fmt.Sprintf("https://iam.%s.otc.t-systems.com/v3", region)
+}
+
+// identityEndpoint returns the identity endpoint of the supplied spec. An
+// explicit identityEndpoint takes precedence over the iam endpoint override,
+// which takes precedence over the endpoint of the region.
+func identityEndpoint(spec v1alpha1.ProviderConfigSpec) string {
+	if spec.IdentityEndpoint != nil && *spec.IdentityEndpoint != "" {
+		return *spec.IdentityEndpoint
+	}
+	if e, ok := spec.Endpoints[ServiceIdentity]; ok && e != "" {
+		return e
+	}
+	return RegionIdentityEndpoint(spec.Region)
+}
+
+// overrideEndpoints makes the supplied provider client resolve the endpoints
+// of the overridden service types without consulting the service catalog.
+// Every service client constructor of the SDK resolves its endpoint through
+// the endpoint locator, so the overrides apply to all of them. The SDK joins
+// resource paths onto the endpoint, so overrides are normalized to end in a
+// slash like the endpoints of the catalog.
+func overrideEndpoints(pc *golangsdk.ProviderClient, endpoints map[string]string) {
+	if len(endpoints) == 0 {
+		return
+	}
+
+	locate := pc.EndpointLocator
+	pc.EndpointLocator = func(eo golangsdk.EndpointOpts) (string, error) {
+		if e, ok := endpoints[eo.Type]; ok && e != "" {
+			return golangsdk.NormalizeURL(e), nil
+		}
+		return locate(eo)
+	}
+
+	// Re-authenticating replaces the endpoint locator and the reauth function
+	// of the provider client, so the overrides are applied again afterwards.
+	if reauth := pc.ReauthFunc; reauth != nil {
+		pc.ReauthFunc = func() error {
+			if err := reauth(); err != nil {
+				return err
+			}
+			overrideEndpoints(pc, endpoints)
+			return nil
+		}
+	}
+}
+
+// endpointsHash returns a stable representation of the supplied endpoint
+// overrides.
+func endpointsHash(endpoints map[string]string) string {
+	keys := make([]string, 0, len(endpoints))
+	for k := range endpoints {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+endpoints[k])
+	}
+	return strings.Join(pairs, ",")
+}
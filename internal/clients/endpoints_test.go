@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+func TestIdentityEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.ProviderConfigSpec
+		want   string
+	}{
+		"RegionEUDE": {
+			reason: "Should derive the identity endpoint from the region",
+			spec:   v1alpha1.ProviderConfigSpec{Region: "eu-de"},
+			want:   "https://iam.eu-de.otc.t-systems.com/v3",
+		},
+		"RegionEUNL": {
+			reason: "Should derive the identity endpoint from the region",
+			spec:   v1alpha1.ProviderConfigSpec{Region: "eu-nl"},
+			want:   "https://iam.eu-nl.otc.t-systems.com/v3",
+		},
+		"RegionEUCH2": {
+			reason: "Should use the identity endpoint of the Swiss cloud",
+			spec:   v1alpha1.ProviderConfigSpec{Region: "eu-ch2"},
+			want:   "https://iam-pub.eu-ch2.sc.otc.t-systems.com/v3",
+		},
+		"Override": {
+			reason: "Should prefer the iam endpoint override over the region",
+			spec: v1alpha1.ProviderConfigSpec{
+				Region:    "eu-de",
+				Endpoints: map[string]string{ServiceIdentity: "http://localhost:8080/v3"},
+			},
+			want: "http://localhost:8080/v3",
+		},
+		"IdentityEndpoint": {
+			reason: "Should prefer the explicit identity endpoint over everything else",
+			spec: v1alpha1.ProviderConfigSpec{
+				Region:           "eu-de",
+				IdentityEndpoint: pointer.To("https://iam.example.com/v3"),
+				Endpoints:        map[string]string{ServiceIdentity: "http://localhost:8080/v3"},
+			},
+			want: "https://iam.example.com/v3",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := identityEndpoint(tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nidentityEndpoint(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOverrideEndpoints(t *testing.T) {
+	catalog := func(eo golangsdk.EndpointOpts) (string, error) {
+		return "https://" + eo.Type + ".catalog/", nil
+	}
+
+	pc := &golangsdk.ProviderClient{EndpointLocator: catalog}
+	pc.ReauthFunc = func() error {
+		// Like the SDK, re-authenticating replaces the endpoint locator.
+		pc.EndpointLocator = catalog
+		return nil
+	}
+
+	overrideEndpoints(pc, map[string]string{
+		"network": "http://localhost:8080/",
+		"vpc":     "http://localhost:8081",
+	})
+
+	for _, reauth := range []bool{false, true} {
+		if reauth {
+			if err := pc.ReauthFunc(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got := map[string]string{}
+		for _, service := range []string{"network", "vpc", "nat"} {
+			e, err := pc.EndpointLocator(golangsdk.EndpointOpts{Type: service})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[service] = e
+		}
+
+		want := map[string]string{
+			"network": "http://localhost:8080/",
+			"vpc":     "http://localhost:8081/",
+			"nat":     "https://nat.catalog/",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("\nShould resolve overridden endpoints (reauth: %t)\npc.EndpointLocator(...): -want, +got:\n%s\n", reauth, diff)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// Reasons of OTC API errors.
+const (
+	// ReasonQuotaExceeded indicates that a request was rejected because a
+	// quota of the project is exhausted.
+	ReasonQuotaExceeded xpv1.ConditionReason = "QuotaExceeded"
+
+	// ReasonInvalidRequest indicates that a request was rejected because
+	// of its content, e.g. an invalid CIDR. Only the documented validation
+	// error codes are recognized.
+	ReasonInvalidRequest xpv1.ConditionReason = "InvalidRequest"
+
+	// ReasonAPIError indicates any other error response of an OTC API.
+	ReasonAPIError xpv1.ConditionReason = "APIError"
+)
+
+// An Error is an error response of an OTC API.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the OTC error code, e.g. VPC.0101.
+	Code string
+
+	// Message is the error message of the response.
+	Message string
+
+	// RequestID identifies the request in the logs of the OTC API.
+	RequestID string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (HTTP %d", e.Message, e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, ", error code %s", e.Code)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request ID %s", e.RequestID)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// Unwrap returns the error of the SDK.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Reason returns the reason of the error.
+func (e *Error) Reason() xpv1.ConditionReason {
+	switch {
+	case e.quota():
+		return ReasonQuotaExceeded
+	case e.invalid():
+		return ReasonInvalidRequest
+	default:
+		return ReasonAPIError
+	}
+}
+
+// Terminal returns true if sending the same request again cannot succeed,
+// because the OTC API rejected its content or the project is out of quota.
+func (e *Error) Terminal() bool {
+	return e.Reason() != ReasonAPIError
+}
+
+// DependencyConflict returns true if the OTC API refused to delete a
+// resource because other resources still depend on it, e.g. a VPC that still
+// has subnets. Only the documented in use error codes are recognized, so
+// other conflicts and rejected requests are reported as they are.
+func (e *Error) DependencyConflict() bool {
+	return inUseCodes[e.Code]
+}
+
+// inUseCodes are the error codes with which the OTC APIs refuse to delete a
+// resource that other resources still depend on.
+var inUseCodes = map[string]bool{
+	// VPC v1: the VPC still has subnets, routes or peerings.
+	"VPC.0005": true,
+	// VPC v1: the subnet still has ports, e.g. of ECSs or NAT gateways.
+	"VPC.0215": true,
+	// VPC v1 and v3: the security group is referenced by ports or by the
+	// rules of other security groups.
+	"VPC.0602": true,
+	// NAT v2: the NAT gateway still has SNAT or DNAT rules.
+	"NAT.0012": true,
+
+	// VPC v2.0 and security groups of the Neutron compatible APIs.
+	"InUse":              true,
+	"NetworkInUse":       true,
+	"SubnetInUse":        true,
+	"SecurityGroupInUse": true,
+	"RouterInUse":        true,
+}
+
+// invalid returns true if the OTC API rejected the content of the request.
+// Other bad requests, e.g. of resources that are not ready yet, may succeed
+// later.
+func (e *Error) invalid() bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return invalidCodes[e.Code]
+	}
+	return false
+}
+
+// invalidCodes are the error codes with which the OTC APIs reject the content
+// of a request.
+var invalidCodes = map[string]bool{
+	// VPC v1 and v3: invalid parameters of VPCs, e.g. their CIDR.
+	"VPC.0002": true,
+	"VPC.0101": true,
+	// VPC v1: invalid parameters of subnets, e.g. a gateway outside the CIDR.
+	"VPC.0201": true,
+	// VPC v1 and v3: invalid parameters of security groups and their rules.
+	"VPC.0601": true,
+	// EIP v1: invalid parameters of EIPs and their bandwidth.
+	"VPC.0301": true,
+	// NAT v2: invalid parameters of NAT gateways and SNAT rules.
+	"NAT.0001": true,
+
+	// VPC v2.0 and security groups of the Neutron compatible APIs.
+	"InvalidInput":     true,
+	"InvalidCIDRRange": true,
+}
+
+func (e *Error) quota() bool {
+	s := strings.ToLower(e.Code + " " + e.Message)
+	return strings.Contains(s, "quota")
+}
+
+// errorBody holds the fields of the error response formats of the OTC APIs.
+type errorBody struct {
+	ErrorCode string `json:"error_code"`
+	ErrorMsg  string `json:"error_msg"`
+	RequestID string `json:"request_id"`
+
+	// Older APIs, e.g. VPC v1, respond with code and message.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// Some APIs, e.g. IAM, nest the error.
+	Error *struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+
+	// Neutron compatible APIs, e.g. VPC v2.0, nest the error, too.
+	NeutronError *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"NeutronError"`
+}
+
+// ParseError returns the OTC API error response of the supplied error, if
+// any.
+func ParseError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var oe *Error
+	if errors.As(err, &oe) {
+		return oe, true
+	}
+
+	resp, ok := unexpectedResponse(err)
+	if !ok {
+		return nil, false
+	}
+
+	e := &Error{StatusCode: resp.Actual, err: err}
+
+	var b errorBody
+	if json.Unmarshal(resp.Body, &b) != nil {
+		e.Message = strings.TrimSpace(string(resp.Body))
+	}
+
+	e.RequestID = b.RequestID
+	switch {
+	case b.ErrorCode != "" || b.ErrorMsg != "":
+		e.Code, e.Message = b.ErrorCode, b.ErrorMsg
+	case b.Code != "" || b.Message != "":
+		e.Code, e.Message = b.Code, b.Message
+	case b.Error != nil:
+		e.Code, e.Message = fmt.Sprint(b.Error.Code), b.Error.Message
+	case b.NeutronError != nil:
+		e.Code, e.Message = b.NeutronError.Type, b.NeutronError.Message
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+	return e, true
+}
+
+// IsTerminal returns true if the supplied error is an OTC API error response
+// that sending the same request again cannot fix.
+func IsTerminal(err error) bool {
+	e, ok := ParseError(err)
+	return ok && e.Terminal()
+}
+
+// unexpectedResponse returns the unexpected response of the supplied SDK
+// error. The SDK returns a distinct type for most status codes, each
+// embedding the unexpected response.
+func unexpectedResponse(err error) (golangsdk.ErrUnexpectedResponseCode, bool) {
+	var (
+		e    golangsdk.ErrUnexpectedResponseCode
+		e400 golangsdk.ErrDefault400
+		e401 golangsdk.ErrDefault401
+		e403 golangsdk.ErrDefault403
+		e404 golangsdk.ErrDefault404
+		e405 golangsdk.ErrDefault405
+		e408 golangsdk.ErrDefault408
+		e409 golangsdk.ErrDefault409
+		e429 golangsdk.ErrDefault429
+		e500 golangsdk.ErrDefault500
+		e503 golangsdk.ErrDefault503
+	)
+	switch {
+	case errors.As(err, &e):
+		return e, true
+	case errors.As(err, &e400):
+		return e400.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e401):
+		return e401.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e403):
+		return e403.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e404):
+		return e404.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e405):
+		return e405.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e408):
+		return e408.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e409):
+		return e409.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e429):
+		return e429.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e500):
+		return e500.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e503):
+		return e503.ErrUnexpectedResponseCode, true
+	}
+	return e, false
+}
+
+// addRequestID adds the X-Request-Id header of the supplied error response
+// to its JSON body. The SDK keeps only the body of error responses, so this
+// is how the request ID reaches ParseError.
+func addRequestID(resp *http.Response) {
+	id := resp.Header.Get(headerRequestID)
+	if resp.StatusCode < http.StatusBadRequest || id == "" || resp.Body == nil ||
+		!strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return
+	}
+	if _, ok := fields["request_id"]; ok {
+		return
+	}
+	fields["request_id"], _ = json.Marshal(id) //nolint:errchkjson // Marshalling a string cannot fail.
+
+	if b, err := json.Marshal(fields); err == nil {
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		resp.ContentLength = int64(len(b))
+		resp.Header.Set("Content-Length", strconv.Itoa(len(b)))
+	}
+}
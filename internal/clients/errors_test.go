@@ -0,0 +1,255 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+func TestParseError(t *testing.T) {
+	type want struct {
+		e        *Error
+		ok       bool
+		terminal bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"NotAnAPIError": {
+			reason: "Should not parse errors other than error responses",
+			err:    errors.New("connection refused"),
+		},
+		"Quota": {
+			reason: "Should classify exhausted quotas as terminal",
+			err: errors.Wrap(golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusBadRequest,
+				Body:   []byte(`{"error_code":"VPC.0115","error_msg":"Quota exceeded for resources: vpc","request_id":"req-1"}`),
+			}}, "cannot create VPC"),
+			want: want{
+				e:        &Error{StatusCode: http.StatusBadRequest, Code: "VPC.0115", Message: "Quota exceeded for resources: vpc", RequestID: "req-1"},
+				ok:       true,
+				terminal: true,
+			},
+		},
+		"InvalidRequest": {
+			reason: "Should classify rejected requests as terminal",
+			err: golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusBadRequest,
+				Body:   []byte(`{"code":"VPC.0101","message":"Invalid CIDR"}`),
+			}},
+			want: want{
+				e:        &Error{StatusCode: http.StatusBadRequest, Code: "VPC.0101", Message: "Invalid CIDR"},
+				ok:       true,
+				terminal: true,
+			},
+		},
+		"NotReady": {
+			reason: "Should not classify bad requests without a validation error code as terminal",
+			err: golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusBadRequest,
+				Body:   []byte(`{"error_code":"VPC.9999","error_msg":"The VPC is not ready yet.","request_id":"req-1"}`),
+			}},
+			want: want{
+				e:  &Error{StatusCode: http.StatusBadRequest, Code: "VPC.9999", Message: "The VPC is not ready yet.", RequestID: "req-1"},
+				ok: true,
+			},
+		},
+		"NeutronInvalidInput": {
+			reason: "Should classify rejected requests of the Neutron compatible APIs as terminal",
+			err: golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusBadRequest,
+				Body:   []byte(`{"NeutronError":{"type":"InvalidInput","message":"Invalid input for operation: 'port' is not a valid port number."}}`),
+			}},
+			want: want{
+				e:        &Error{StatusCode: http.StatusBadRequest, Code: "InvalidInput", Message: "Invalid input for operation: 'port' is not a valid port number."},
+				ok:       true,
+				terminal: true,
+			},
+		},
+		"NeutronError": {
+			reason: "Should parse the error format of the Neutron compatible APIs",
+			err: golangsdk.ErrDefault409{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusConflict,
+				Body:   []byte(`{"NeutronError":{"type":"InUse","message":"Subnet is in use"}}`),
+			}},
+			want: want{
+				e:  &Error{StatusCode: http.StatusConflict, Code: "InUse", Message: "Subnet is in use"},
+				ok: true,
+			},
+		},
+		"ServerError": {
+			reason: "Should not classify server errors as terminal",
+			err: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusBadGateway,
+				Body:   []byte(`<html>bad gateway</html>`),
+			},
+			want: want{
+				e:  &Error{StatusCode: http.StatusBadGateway, Message: "<html>bad gateway</html>"},
+				ok: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, ok := ParseError(tc.err)
+			if diff := cmp.Diff(tc.want.e, e, cmpopts.IgnoreUnexported(Error{})); diff != "" {
+				t.Errorf("\n%s\nParseError(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nParseError(...): -want ok, +got ok:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.terminal, IsTerminal(tc.err)); diff != "" {
+				t.Errorf("\n%s\nIsTerminal(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDependencyConflict(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		code   int
+		body   string
+		want   bool
+	}{
+		"VPCHasSubnets": {
+			reason: "Should recognize a VPC that still has subnets",
+			code:   http.StatusBadRequest,
+			body:   `{"code":"VPC.0005","message":"Delete vpc failed, because there are subnets in the vpc."}`,
+			want:   true,
+		},
+		"SubnetHasPorts": {
+			reason: "Should recognize a subnet that still has ports",
+			code:   http.StatusBadRequest,
+			body:   `{"code":"VPC.0215","message":"Delete subnet failed, because the subnet has ports in use."}`,
+			want:   true,
+		},
+		"SecurityGroupInUse": {
+			reason: "Should recognize a security group that is still in use",
+			code:   http.StatusBadRequest,
+			body:   `{"error_code":"VPC.0602","error_msg":"Security group is in use by ports or rules of other security groups.","request_id":"req-1"}`,
+			want:   true,
+		},
+		"NATGatewayHasRules": {
+			reason: "Should recognize a NAT gateway that still has rules",
+			code:   http.StatusBadRequest,
+			body:   `{"error_code":"NAT.0012","error_msg":"Nat gateway has snat or dnat rules."}`,
+			want:   true,
+		},
+		"NeutronSubnetInUse": {
+			reason: "Should recognize the in use errors of the Neutron compatible APIs",
+			code:   http.StatusConflict,
+			body:   `{"NeutronError":{"type":"SubnetInUse","message":"Unable to complete operation on subnet. One or more ports have an IP allocation from this subnet."}}`,
+			want:   true,
+		},
+		"OtherConflict": {
+			reason: "Should not treat every conflict as a dependency conflict",
+			code:   http.StatusConflict,
+			body:   `{"NeutronError":{"type":"IpAddressAlreadyAllocated","message":"IP address 192.168.0.10 already allocated in subnet."}}`,
+		},
+		"StillInMessage": {
+			reason: "Should not guess dependency conflicts from the message",
+			code:   http.StatusBadRequest,
+			body:   `{"code":"VPC.0101","message":"The CIDR still overlaps with an existing subnet."}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, ok := ParseError(golangsdk.ErrUnexpectedResponseCode{Actual: tc.code, Body: []byte(tc.body)})
+			if !ok {
+				t.Fatalf("\n%s\nParseError(...): want an API error\n", tc.reason)
+			}
+			if diff := cmp.Diff(tc.want, e.DependencyConflict()); diff != "" {
+				t.Errorf("\n%s\ne.DependencyConflict(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAddRequestID(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status int
+		body   string
+		want   string
+	}{
+		"ErrorResponse": {
+			reason: "Should add the request ID to JSON error responses",
+			status: http.StatusBadRequest,
+			body:   `{"code":"VPC.0101"}`,
+			want:   `{"code":"VPC.0101","request_id":"req-1"}`,
+		},
+		"RequestIDInBody": {
+			reason: "Should keep the request ID of the body",
+			status: http.StatusBadRequest,
+			body:   `{"request_id":"req-0"}`,
+			want:   `{"request_id":"req-0"}`,
+		},
+		"Success": {
+			reason: "Should not touch successful responses",
+			status: http.StatusOK,
+			body:   `{"vpc":{}}`,
+			want:   `{"vpc":{}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tc.status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}, headerRequestID: []string{"req-1"}},
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			}
+
+			addRequestID(resp)
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\naddRequestID(...): -want body, +got body:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogRequest(t *testing.T) {
+	ctx, log := WithRequestLog(context.Background())
+
+	for _, r := range []struct {
+		method string
+		status int
+		id     string
+	}{
+		{method: http.MethodGet, status: http.StatusOK, id: "get"},
+		{method: http.MethodPost, status: http.StatusCreated, id: "post"},
+		{method: http.MethodPut, status: http.StatusBadRequest, id: "failed"},
+		{method: http.MethodDelete, status: http.StatusNoContent, id: "delete"},
+	} {
+		req, err := http.NewRequestWithContext(ctx, r.method, "https://vpc.eu-de.otc.t-systems.com/v1/vpcs", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		logRequest(req, &http.Response{StatusCode: r.status, Header: http.Header{headerRequestID: []string{r.id}}})
+	}
+
+	if diff := cmp.Diff([]string{"post", "delete"}, log.Since(0)); diff != "" {
+		t.Errorf("log.Since(0): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"delete"}, log.Since(1)); diff != "" {
+		t.Errorf("log.Since(1): -want, +got:\n%s\n", diff)
+	}
+}
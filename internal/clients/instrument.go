@@ -0,0 +1,210 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
+)
+
+const (
+	statusNetworkError = "error"
+
+	// headerRequestID identifies a request in the logs of the OTC APIs.
+	headerRequestID = "X-Request-Id"
+)
+
+// Span attributes of OTC API requests and sessions.
+const (
+	attrService        = attribute.Key("otc.service")
+	attrProviderConfig = attribute.Key("otc.providerconfig")
+	attrRequestID      = attribute.Key("otc.request_id")
+	attrCacheHit       = attribute.Key("otc.session_cache_hit")
+)
+
+var (
+	versionSegment = regexp.MustCompile(`^v\d+(\.\d+)?$`)
+	idSegment      = regexp.MustCompile(`^([0-9a-fA-F]{32}|[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}|\d+)$`)
+)
+
+// instrumentTransport records the number and latency of the requests to the
+// OTC APIs that are sent for a ProviderConfig.
+type instrumentTransport struct {
+	next    http.RoundTripper
+	metrics *apiMetrics
+
+	// providerConfig is the cache key of the ProviderConfig.
+	providerConfig string
+
+	// services maps endpoint overrides to their service types, longest
+	// first.
+	services []serviceEndpoint
+}
+
+type serviceEndpoint struct {
+	url         string
+	serviceType string
+}
+
+func newInstrumentTransport(
+	next http.RoundTripper,
+	m *apiMetrics,
+	providerConfig string,
+	identityEndpoint string,
+	endpoints map[string]string,
+) *instrumentTransport {
+	var services []serviceEndpoint
+	// The identity service is reached under several API versions, so all
+	// requests to its host belong to it.
+	if u, err := url.Parse(identityEndpoint); err == nil && u.Host != "" {
+		services = append(services, serviceEndpoint{url: u.Scheme + "://" + u.Host, serviceType: ServiceIdentity})
+	}
+	for st, endpoint := range endpoints {
+		services = append(services, serviceEndpoint{url: endpoint, serviceType: st})
+	}
+	sort.Slice(services, func(i, j int) bool { return len(services[i].url) > len(services[j].url) })
+
+	return &instrumentTransport{
+		next:           next,
+		metrics:        m,
+		providerConfig: providerConfig,
+		services:       services,
+	}
+}
+
+// RoundTrip sends the supplied request and records its outcome as metrics
+// and as a span.
+func (t *instrumentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	service, operation := t.describe(req)
+
+	_, span := tracing.Start(req.Context(), operation,
+		attrService.String(service),
+		attrProviderConfig.String(t.providerConfig),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.Redacted()),
+	)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	status := statusNetworkError
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+		addRequestID(resp)
+		logRequest(req, resp)
+		span.SetAttributes(
+			semconv.HTTPResponseStatusCode(resp.StatusCode),
+			attrRequestID.String(resp.Header.Get(headerRequestID)),
+		)
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	tracing.End(span, err)
+
+	t.metrics.requests.WithLabelValues(service, operation, status, t.providerConfig).Inc()
+	t.metrics.latency.WithLabelValues(service, operation, status, t.providerConfig).Observe(elapsed.Seconds())
+	return resp, err
+}
+
+// describe returns the service and the operation of the supplied request.
+// The service is the service type of a matching endpoint override, or else
+// the first label of the host name, e.g. vpc for vpc.eu-de.otc.t-systems.com.
+// It includes the API version if the path starts with one. The operation is
+// the HTTP method and the path, with resource and project IDs replaced by
+// placeholders to keep the number of distinct operations bounded.
+func (t *instrumentTransport) describe(req *http.Request) (string, string) {
+	u := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+
+	service := req.URL.Hostname()
+	if net.ParseIP(service) == nil {
+		service = strings.SplitN(service, ".", 2)[0]
+	}
+	for _, s := range t.services {
+		base := strings.TrimSuffix(s.url, "/")
+		if base != "" && (u == base || strings.HasPrefix(u, base+"/")) {
+			service = s.serviceType
+			break
+		}
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) > 0 && versionSegment.MatchString(segments[0]) {
+		service += "/" + segments[0]
+		segments = segments[1:]
+	}
+
+	for i, s := range segments {
+		if !idSegment.MatchString(s) {
+			continue
+		}
+		// Project scoped APIs start with the project ID.
+		if i == 0 && len(s) == 32 {
+			segments[i] = "{project_id}"
+			continue
+		}
+		segments[i] = "{id}"
+	}
+
+	return service, req.Method + " /" + strings.Join(segments, "/")
+}
+
+// bind returns a copy of the supplied provider client whose requests belong
+// to the trace and the request log of the supplied context. The SDK does not
+// pass contexts to its requests, so the requests would otherwise start traces
+// of their own, and their retries would outlast the reconcile.
+func bind(ctx context.Context, pc *golangsdk.ProviderClient) *golangsdk.ProviderClient {
+	if _, ok := requestLogFrom(ctx); !ok && !trace.SpanContextFromContext(ctx).IsValid() && ctx.Done() == nil {
+		return pc
+	}
+
+	bound := *pc
+	bound.HTTPClient.Transport = &contextTransport{
+		// Requests in flight are not cancelled along with the reconcile, as
+		// before, but their retries stop waiting once it is done.
+		ctx:  withRetryContext(context.WithoutCancel(ctx), ctx),
+		next: pc.HTTPClient.Transport,
+	}
+
+	// Re-authenticating renews the token of the original provider client,
+	// which the copy has to pick up. The SDK holds the token lock meanwhile.
+	if reauth := pc.ReauthFunc; reauth != nil {
+		bound.ReauthFunc = func() error {
+			if err := reauth(); err != nil {
+				return err
+			}
+			bound.TokenID = pc.TokenID
+			bound.EndpointLocator = pc.EndpointLocator
+			return nil
+		}
+	}
+	return &bound
+}
+
+// contextTransport sends requests with the supplied context.
+type contextTransport struct {
+	ctx  context.Context //nolint:containedctx // The SDK offers no other way to pass it.
+	next http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req.WithContext(t.ctx))
+}
@@ -0,0 +1,161 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInstrumentTransportDescribe(t *testing.T) {
+	type args struct {
+		endpoints map[string]string
+		method    string
+		url       string
+	}
+
+	type want struct {
+		service   string
+		operation string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ProjectScoped": {
+			reason: "Should replace the project and resource IDs with placeholders",
+			args: args{
+				method: http.MethodGet,
+				url:    "https://vpc.eu-de.otc.t-systems.com/v1/0123456789abcdef0123456789abcdef/vpcs/4dcd6f6b-9b9a-4d4b-8a3a-2ef0a1c0e9b1?limit=1",
+			},
+			want: want{service: "vpc/v1", operation: "GET /{project_id}/vpcs/{id}"},
+		},
+		"NotProjectScoped": {
+			reason: "Should keep paths without IDs",
+			args: args{
+				method: http.MethodPost,
+				url:    "https://vpc.eu-de.otc.t-systems.com/v2.0/security-group-rules",
+			},
+			want: want{service: "vpc/v2.0", operation: "POST /security-group-rules"},
+		},
+		"Identity": {
+			reason: "Should attribute all requests to the identity host to the identity service",
+			args: args{
+				method: http.MethodPost,
+				url:    "https://iam-pub.eu-ch2.sc.otc.t-systems.com/v3.0/OS-CREDENTIAL/securitytokens",
+			},
+			want: want{service: "iam/v3.0", operation: "POST /OS-CREDENTIAL/securitytokens"},
+		},
+		"EndpointOverride": {
+			reason: "Should use the service type of a matching endpoint override",
+			args: args{
+				endpoints: map[string]string{"nat": "https://proxy.example.com/nat/"},
+				method:    http.MethodDelete,
+				url:       "https://proxy.example.com/nat/v2.0/nat_gateways/4dcd6f6b-9b9a-4d4b-8a3a-2ef0a1c0e9b1",
+			},
+			want: want{service: "nat", operation: "DELETE /nat/v2.0/nat_gateways/{id}"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rt := newInstrumentTransport(http.DefaultTransport, newAPIMetrics(), "ProviderConfig/default/otc",
+				"https://iam-pub.eu-ch2.sc.otc.t-systems.com/v3", tc.args.endpoints)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.args.method, tc.args.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service, operation := rt.describe(req)
+			if diff := cmp.Diff(tc.want, want{service: service, operation: operation}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nrt.describe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInstrumentTransportRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	m := newAPIMetrics()
+	rt := newInstrumentTransport(http.DefaultTransport, m, "ClusterProviderConfig/otc", "", nil)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/v1/vpcs", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+
+	got := testutil.ToFloat64(m.requests.WithLabelValues("127.0.0.1/v1", "GET /vpcs", "404", "ClusterProviderConfig/otc"))
+	if diff := cmp.Diff(1.0, got); diff != "" {
+		t.Errorf("rt.RoundTrip(...): -want requests, +got:\n%s\n", diff)
+	}
+
+	m.forget("ClusterProviderConfig/otc")
+	if diff := cmp.Diff(0, testutil.CollectAndCount(m.requests)); diff != "" {
+		t.Errorf("m.forget(...): -want series, +got:\n%s\n", diff)
+	}
+}
+
+func TestInstrumentTransportSpan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(headerRequestID, "req-1")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	pc := &golangsdk.ProviderClient{}
+	pc.HTTPClient.Transport = newInstrumentTransport(http.DefaultTransport, newAPIMetrics(), "ClusterProviderConfig/otc", "", nil)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "Observe")
+	if _, err := bind(ctx, pc).Request(http.MethodGet, srv.URL+"/v1/vpcs", &golangsdk.RequestOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("want 2 spans, got %d", len(spans))
+	}
+
+	got := spans[0]
+	if diff := cmp.Diff(parent.SpanContext().SpanID(), got.Parent().SpanID()); diff != "" {
+		t.Errorf("parent span: -want, +got:\n%s\n", diff)
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range got.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if diff := cmp.Diff("req-1", attrs[attrRequestID].AsString()); diff != "" {
+		t.Errorf("request ID attribute: -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("GET /vpcs", got.Name()); diff != "" {
+		t.Errorf("span name: -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsSubsystem = "otc"
+
+// cacheMetrics counts how the session cache serves client requests.
+type cacheMetrics struct {
+	hits        prometheus.Counter
+	misses      prometheus.Counter
+	logins      prometheus.Counter
+	loginErrors prometheus.Counter
+	evictions   prometheus.Counter
+}
+
+func newCacheMetrics() *cacheMetrics {
+	return &cacheMetrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "session_cache_hits_total",
+			Help:      "The number of client requests served from a cached session",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "session_cache_misses_total",
+			Help:      "The number of client requests without a valid cached session",
+		}),
+		logins: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "session_logins_total",
+			Help:      "The number of authentications against the OTC identity service",
+		}),
+		loginErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "session_login_errors_total",
+			Help:      "The number of failed authentications against the OTC identity service",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "session_evictions_total",
+			Help:      "The number of sessions evicted because their ProviderConfig was deleted",
+		}),
+	}
+}
+
+func (m *cacheMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.hits, m.misses, m.logins, m.loginErrors, m.evictions}
+}
+
+// apiMetrics counts how requests to the OTC APIs are handled.
+type apiMetrics struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	retries   *prometheus.CounterVec
+	throttles *prometheus.CounterVec
+}
+
+func newAPIMetrics() *apiMetrics {
+	labels := []string{"service", "operation", "status", "providerconfig"}
+	return &apiMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "api_requests_total",
+			Help:      "The number of OTC API requests by service, operation, HTTP status and ProviderConfig",
+		}, labels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "api_request_duration_seconds",
+			Help:      "The latency of OTC API requests by service, operation, HTTP status and ProviderConfig",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "api_retries_total",
+			Help:      "The number of retried OTC API requests by HTTP method and reason",
+		}, []string{"method", "reason"}),
+		throttles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "api_throttles_total",
+			Help:      "The number of OTC API requests rejected with 429 Too Many Requests by HTTP method",
+		}, []string{"method"}),
+	}
+}
+
+func (m *apiMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.latency, m.retries, m.throttles}
+}
+
+// forget removes the request metrics of the supplied ProviderConfig.
+func (m *apiMetrics) forget(providerConfig string) {
+	l := prometheus.Labels{"providerconfig": providerConfig}
+	m.requests.DeletePartialMatch(l)
+	m.latency.DeletePartialMatch(l)
+}
+
+// Describe sends the metric descriptors of the cache and of the requests of
+// its sessions to the supplied channel.
+// Together with Collect it allows registering the Cache with Prometheus.
+func (c *Cache) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range append(c.metrics.collectors(), c.api.collectors()...) {
+		m.Describe(ch)
+	}
+}
+
+// Collect sends the current metric values of the cache to the supplied channel.
+func (c *Cache) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range append(c.metrics.collectors(), c.api.collectors()...) {
+		m.Collect(ch)
+	}
+}
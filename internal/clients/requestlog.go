@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type requestLogKey struct{}
+
+// A RequestLog collects the request IDs of the mutating OTC API requests that
+// clients send on behalf of a managed resource.
+type RequestLog struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+// WithRequestLog returns a context whose clients record the request IDs of
+// their mutating requests in the returned RequestLog.
+func WithRequestLog(ctx context.Context) (context.Context, *RequestLog) {
+	l := &RequestLog{}
+	return context.WithValue(ctx, requestLogKey{}, l), l
+}
+
+// Len returns the number of recorded request IDs.
+func (l *RequestLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.ids)
+}
+
+// Since returns the request IDs recorded after the first n ones.
+func (l *RequestLog) Since(n int) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n >= len(l.ids) {
+		return nil
+	}
+	return append([]string(nil), l.ids[n:]...)
+}
+
+func (l *RequestLog) add(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ids = append(l.ids, id)
+}
+
+func requestLogFrom(ctx context.Context) (*RequestLog, bool) {
+	l, ok := ctx.Value(requestLogKey{}).(*RequestLog)
+	return l, ok
+}
+
+// logRequest records the request ID of the supplied response if its request
+// mutated a resource. The IDs of failed requests are part of their errors
+// instead, see ParseError.
+func logRequest(req *http.Request, resp *http.Response) {
+	switch {
+	case resp.StatusCode >= http.StatusBadRequest:
+		return
+	case req.Method == http.MethodGet, req.Method == http.MethodHead, req.Method == http.MethodOptions:
+		return
+	}
+	id := resp.Header.Get(headerRequestID)
+	if l, ok := requestLogFrom(req.Context()); ok && id != "" {
+		l.add(id)
+	}
+}
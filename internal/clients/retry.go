@@ -0,0 +1,215 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+	defaultMaxDelay   = 30 * time.Second
+
+	reasonNetworkError = "error"
+)
+
+// retryPolicy is a RetryPolicy with the defaults applied.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func newRetryPolicy(p *v1alpha1.RetryPolicy) retryPolicy {
+	rp := retryPolicy{
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultBaseDelay,
+		maxDelay:   defaultMaxDelay,
+	}
+	if p == nil {
+		return rp
+	}
+
+	rp.maxRetries = pointer.Deref(p.MaxRetries, rp.maxRetries)
+	if p.BaseDelay != nil {
+		rp.baseDelay = p.BaseDelay.Duration
+	}
+	if p.MaxDelay != nil {
+		rp.maxDelay = p.MaxDelay.Duration
+	}
+	return rp
+}
+
+// String returns a representation of the policy that changes whenever one of
+// its settings changes.
+func (p retryPolicy) String() string {
+	return fmt.Sprintf("%d/%s/%s", p.maxRetries, p.baseDelay, p.maxDelay)
+}
+
+// backoff returns the jittered exponential delay before the supplied retry,
+// starting with retry 0.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	d := p.maxDelay
+	if retry < 32 {
+		if b := p.baseDelay << retry; b > 0 && b < d {
+			d = b
+		}
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Full jitter spreads the retries of parallel reconciles.
+	return rand.N(d) //nolint:gosec // No need for a secure random number.
+}
+
+// retryTransport retries failed requests to the OTC APIs.
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  retryPolicy
+	metrics *apiMetrics
+
+	// wait waits for the supplied duration unless the supplied context is
+	// done first. It is replaced in tests.
+	wait func(context.Context, time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, p *v1alpha1.RetryPolicy, m *apiMetrics) *retryTransport {
+	return &retryTransport{
+		next:    next,
+		policy:  newRetryPolicy(p),
+		metrics: m,
+		wait:    wait,
+	}
+}
+
+// wait waits for the supplied duration, or returns the error of the supplied
+// context if it is done first.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryContextKey is the context key of the context retries wait with.
+type retryContextKey struct{}
+
+// withRetryContext returns a copy of the supplied context whose requests wait
+// for their retries with the supplied retry context, e.g. the context of a
+// reconcile whose requests must not be cancelled along with it.
+func withRetryContext(ctx, retry context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retry)
+}
+
+// retryContext returns the context a request with the supplied context waits
+// for its retries with.
+func retryContext(ctx context.Context) context.Context {
+	if retry, ok := ctx.Value(retryContextKey{}).(context.Context); ok {
+		return retry
+	}
+	return ctx
+}
+
+// RoundTrip sends the supplied request and retries it if it fails in a way
+// that is safe to retry. Retries are sent as clones of the supplied request,
+// which is never modified.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := req
+	for retry := 0; ; retry++ {
+		resp, err := t.next.RoundTrip(attempt)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			t.metrics.throttles.WithLabelValues(req.Method).Inc()
+		}
+
+		reason, ok := retryable(req, resp, err)
+		if !ok || retry >= t.policy.maxRetries {
+			return resp, err
+		}
+
+		// A request with a body can only be sent again if the body can be
+		// restored.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, berr := req.GetBody()
+			if berr != nil {
+				return resp, err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		delay := t.policy.backoff(retry)
+		if resp != nil {
+			if after, ok := retryAfter(resp, time.Now()); ok {
+				delay = min(after, t.policy.maxDelay)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+		}
+
+		t.metrics.retries.WithLabelValues(req.Method, reason).Inc()
+		if err := t.wait(retryContext(req.Context()), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable returns the reason why the outcome of the supplied request may be
+// retried, and whether it may be retried at all. Throttled requests were not
+// processed and can always be retried. Requests that failed otherwise may
+// have been processed, so only idempotent requests are retried.
+func retryable(req *http.Request, resp *http.Response, err error) (string, bool) {
+	if err != nil {
+		return reasonNetworkError, idempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return strconv.Itoa(resp.StatusCode), true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return strconv.Itoa(resp.StatusCode), idempotent(req.Method)
+	}
+	return "", false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the
+// supplied response. The header holds either seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
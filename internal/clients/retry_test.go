@@ -0,0 +1,297 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+// respond returns a handler that answers with the supplied status codes in
+// order and records the request bodies.
+func respond(t *testing.T, bodies *[]string, headers http.Header, codes ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*bodies = append(*bodies, string(b))
+
+		code := codes[min(len(*bodies), len(codes))-1]
+		if code == http.StatusTooManyRequests {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+		}
+		w.WriteHeader(code)
+	}
+}
+
+// total returns the sum of all counters of the supplied collector.
+func total(t *testing.T, c prometheus.Collector) float64 {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	var sum float64
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		sum += pb.GetCounter().GetValue()
+	}
+	return sum
+}
+
+func TestRetryTransport(t *testing.T) {
+	type args struct {
+		method  string
+		codes   []int
+		headers http.Header
+		policy  *v1alpha1.RetryPolicy
+	}
+
+	type want struct {
+		code      int
+		attempts  int
+		retries   float64
+		throttles float64
+		delays    []time.Duration
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "Should not retry successful requests",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{http.StatusOK},
+			},
+			want: want{code: http.StatusOK, attempts: 1},
+		},
+		"GetServiceUnavailable": {
+			reason: "Should retry idempotent requests on transient server errors",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			},
+			want: want{code: http.StatusOK, attempts: 3, retries: 2},
+		},
+		"PostServiceUnavailable": {
+			reason: "Should not retry POST requests on server errors, they may have been processed",
+			args: args{
+				method: http.MethodPost,
+				codes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			},
+			want: want{code: http.StatusServiceUnavailable, attempts: 1},
+		},
+		"PostThrottled": {
+			reason: "Should retry throttled POST requests after the delay requested by Retry-After",
+			args: args{
+				method:  http.MethodPost,
+				codes:   []int{http.StatusTooManyRequests, http.StatusCreated},
+				headers: http.Header{"Retry-After": []string{"7"}},
+			},
+			want: want{code: http.StatusCreated, attempts: 2, retries: 1, throttles: 1, delays: []time.Duration{7 * time.Second}},
+		},
+		"RetryAfterCapped": {
+			reason: "Should not wait longer than the maximum delay",
+			args: args{
+				method:  http.MethodDelete,
+				codes:   []int{http.StatusTooManyRequests, http.StatusNoContent},
+				headers: http.Header{"Retry-After": []string{"3600"}},
+				policy:  &v1alpha1.RetryPolicy{MaxDelay: &metav1.Duration{Duration: 10 * time.Second}},
+			},
+			want: want{code: http.StatusNoContent, attempts: 2, retries: 1, throttles: 1, delays: []time.Duration{10 * time.Second}},
+		},
+		"RetriesExhausted": {
+			reason: "Should return the last response once the retries are exhausted",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{http.StatusTooManyRequests},
+				policy: &v1alpha1.RetryPolicy{MaxRetries: pointer.To(2)},
+			},
+			want: want{code: http.StatusTooManyRequests, attempts: 3, retries: 2, throttles: 3},
+		},
+		"Disabled": {
+			reason: "Should not retry when retries are disabled",
+			args: args{
+				method: http.MethodGet,
+				codes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+				policy: &v1alpha1.RetryPolicy{MaxRetries: pointer.To(0)},
+			},
+			want: want{code: http.StatusServiceUnavailable, attempts: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var bodies []string
+			srv := httptest.NewServer(respond(t, &bodies, tc.args.headers, tc.args.codes...))
+			defer srv.Close()
+
+			m := newAPIMetrics()
+			rt := newRetryTransport(http.DefaultTransport, tc.args.policy, m)
+			var delays []time.Duration
+			rt.wait = func(_ context.Context, d time.Duration) error {
+				if tc.args.headers != nil {
+					delays = append(delays, d)
+				}
+				return nil
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.args.method, srv.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body := req.Body
+			resp, err := (&http.Client{Transport: rt}).Do(req)
+			if err != nil {
+				t.Fatalf("\n%s\nDo(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+			if req.Body != body {
+				t.Errorf("\n%s\nretryTransport.RoundTrip(...): must not replace the body of the supplied request\n", tc.reason)
+			}
+			resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+
+			got := want{
+				code:      resp.StatusCode,
+				attempts:  len(bodies),
+				retries:   total(t, m.retries),
+				throttles: total(t, m.throttles),
+				delays:    delays,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nretryTransport.RoundTrip(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+
+			for i, b := range bodies {
+				if b != "body" {
+					t.Errorf("\n%s\nattempt %d: -want body %q, +got %q\n", tc.reason, i, "body", b)
+				}
+			}
+		})
+	}
+}
+
+// roundTripFunc is an http.RoundTripper implemented by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	next := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+		// The reconcile is cancelled while the request is being throttled.
+		cancel()
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	rt := newRetryTransport(next, &v1alpha1.RetryPolicy{
+		BaseDelay: &metav1.Duration{Duration: time.Hour},
+		MaxDelay:  &metav1.Duration{Duration: time.Hour},
+	}, newAPIMetrics())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://otc.example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := rt.RoundTrip(req)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("retryTransport.RoundTrip(...): want context.Canceled, got %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("retryTransport.RoundTrip(...): want 1 attempt, got %d", attempts)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("retryTransport.RoundTrip(...): must stop waiting once the context of the request is done")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := newRetryPolicy(&v1alpha1.RetryPolicy{
+		BaseDelay: &metav1.Duration{Duration: time.Second},
+		MaxDelay:  &metav1.Duration{Duration: 5 * time.Second},
+	})
+
+	for retry, limit := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for range 100 {
+			if d := p.backoff(retry); d < 0 || d >= limit {
+				t.Fatalf("p.backoff(%d): want delay in [0, %s), got %s", retry, limit, d)
+			}
+		}
+	}
+}
+
+func TestBindRetryContextDone(t *testing.T) {
+	requests := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests <- struct{}{}
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	pc := &golangsdk.ProviderClient{}
+	pc.HTTPClient.Transport = newRetryTransport(http.DefaultTransport, nil, newAPIMetrics())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := bind(ctx, pc).Request(http.MethodGet, srv.URL+"/v1/vpcs", &golangsdk.RequestOpts{})
+		done <- err
+	}()
+
+	// The reconcile is cancelled while the request waits for its retry.
+	<-requests
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("bind(...).Request(...): want context.Canceled, got %v", err)
+		}
+		if n := len(requests); n != 0 {
+			t.Errorf("bind(...).Request(...): want no retries after the reconcile is cancelled, got %d", n)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("bind(...).Request(...): must stop waiting for a retry once the reconcile is cancelled")
+	}
+}
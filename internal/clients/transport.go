@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+// transport holds the transport settings of a ProviderConfig with the
+// referenced secrets resolved.
+type transport struct {
+	proxyURL           string
+	caBundle           []byte
+	clientCert         []byte
+	clientKey          []byte
+	insecureSkipVerify bool
+}
+
+// extractTransport resolves the supplied transport settings.
+func extractTransport(ctx context.Context, kube client.Client, spec *v1alpha1.Transport) (*transport, error) {
+	t := &transport{}
+	if spec == nil {
+		return t, nil
+	}
+
+	t.proxyURL = pointer.Deref(spec.ProxyURL, "")
+	t.insecureSkipVerify = pointer.Deref(spec.InsecureSkipVerify, false)
+
+	if ref := spec.CABundleSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrap(err, "cannot get CA bundle secret")
+		}
+		if t.caBundle = s.Data[ref.Key]; len(t.caBundle) == 0 {
+			return nil, errors.Errorf("CA bundle secret has no key %s", ref.Key)
+		}
+	}
+
+	if ref := spec.ClientCertificateSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrap(err, "cannot get client certificate secret")
+		}
+		t.clientCert, t.clientKey = s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey]
+		if len(t.clientCert) == 0 || len(t.clientKey) == 0 {
+			return nil, errors.Errorf("client certificate secret must contain %s and %s", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+	}
+
+	return t, nil
+}
+
+// hash returns a representation of the transport settings that changes
+// whenever one of them changes.
+func (t *transport) hash() string {
+	return fmt.Sprintf("%s|%x|%x|%x|%t", t.proxyURL, t.caBundle, t.clientCert, t.clientKey, t.insecureSkipVerify)
+}
+
+// httpClient returns an HTTP client that uses the transport settings.
+func (t *transport) httpClient() (http.Client, error) {
+	rt := http.DefaultTransport.(*http.Transport).Clone()
+
+	if t.proxyURL != "" {
+		u, err := url.Parse(t.proxyURL)
+		if err != nil {
+			return http.Client{}, errors.Wrap(err, "cannot parse proxy URL")
+		}
+		rt.Proxy = http.ProxyURL(u)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.insecureSkipVerify, //nolint:gosec // Explicitly requested by the ProviderConfig.
+	}
+
+	if len(t.caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(t.caBundle) {
+			return http.Client{}, errors.New("CA bundle contains no PEM encoded certificates")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(t.clientCert) > 0 {
+		cert, err := tls.X509KeyPair(t.clientCert, t.clientKey)
+		if err != nil {
+			return http.Client{}, errors.Wrap(err, "cannot load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	rt.TLSClientConfig = cfg
+	return http.Client{Transport: rt}, nil
+}
+
+// authenticate logs in with the supplied options. All requests of the
+// returned provider client, including the login, use the supplied HTTP
+// client.
+func authenticate(opts golangsdk.AuthOptionsProvider, hc http.Client) (*golangsdk.ProviderClient, error) {
+	pc, err := openstack.NewClient(opts.GetIdentityEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	pc.HTTPClient = hc
+
+	if err := openstack.Authenticate(pc, opts); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
@@ -0,0 +1,176 @@
+package clients
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+func TestExtractTransport(t *testing.T) {
+	caRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "default"},
+		Key:             "ca.crt",
+	}
+
+	type args struct {
+		kube client.Client
+		spec *v1alpha1.Transport
+	}
+
+	type want struct {
+		t   *transport
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoTransport": {
+			reason: "Should use the defaults without transport settings",
+			want: want{
+				t: &transport{},
+			},
+		},
+		"Settings": {
+			reason: "Should resolve the proxy, the CA bundle and insecureSkipVerify",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"ca.crt": []byte("bundle")}
+						return nil
+					}),
+				},
+				spec: &v1alpha1.Transport{
+					ProxyURL:           pointer.To("http://proxy:3128"),
+					CABundleSecretRef:  caRef,
+					InsecureSkipVerify: pointer.To(true),
+				},
+			},
+			want: want{
+				t: &transport{
+					proxyURL:           "http://proxy:3128",
+					caBundle:           []byte("bundle"),
+					insecureSkipVerify: true,
+				},
+			},
+		},
+		"MissingCABundleKey": {
+			reason: "Should fail when the CA bundle secret has no such key",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				spec: &v1alpha1.Transport{CABundleSecretRef: caRef},
+			},
+			want: want{
+				err: fmt.Errorf("CA bundle secret has no key ca.crt"),
+			},
+		},
+		"MissingClientKey": {
+			reason: "Should fail when the client certificate secret has no private key",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{corev1.TLSCertKey: []byte("cert")}
+						return nil
+					}),
+				},
+				spec: &v1alpha1.Transport{
+					ClientCertificateSecretRef: &xpv1.SecretReference{Name: "cert", Namespace: "default"},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("client certificate secret must contain tls.crt and tls.key"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := extractTransport(context.Background(), tc.args.kube, tc.args.spec)
+
+			if tc.want.err != nil {
+				if err == nil {
+					t.Errorf("\n%s\nextractTransport(...): -want error, +got nil\n", tc.reason)
+				} else if !strings.Contains(err.Error(), tc.want.err.Error()) {
+					t.Errorf("\n%s\nextractTransport(...): -want error containing %q, +got %q\n", tc.reason, tc.want.err.Error(), err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("\n%s\nextractTransport(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.t, got, cmp.AllowUnexported(transport{})); diff != "" {
+				t.Errorf("\n%s\nextractTransport(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTransportHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	cases := map[string]struct {
+		reason string
+		t      *transport
+		wantOK bool
+	}{
+		"UntrustedServer": {
+			reason: "Should reject a server certificate of an unknown CA",
+			t:      &transport{},
+		},
+		"CABundle": {
+			reason: "Should trust server certificates issued by the CA bundle",
+			t:      &transport{caBundle: ca},
+			wantOK: true,
+		},
+		"InsecureSkipVerify": {
+			reason: "Should skip certificate verification when requested",
+			t:      &transport{insecureSkipVerify: true},
+			wantOK: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hc, err := tc.t.httpClient()
+			if err != nil {
+				t.Fatalf("\n%s\nt.httpClient(): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := hc.Do(req)
+			if err == nil {
+				resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+			}
+
+			if diff := cmp.Diff(tc.wantOK, err == nil); diff != "" {
+				t.Errorf("\n%s\nhc.Do(...): -want success, +got (error: %v):\n%s\n", tc.reason, err, diff)
+			}
+		})
+	}
+}
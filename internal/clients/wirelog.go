@@ -0,0 +1,192 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+)
+
+const (
+	// redacted replaces secrets in wire logs.
+	redacted = "REDACTED"
+
+	// maxLoggedBody caps the logged size of a body. Token responses carry
+	// the whole service catalog.
+	maxLoggedBody = 4096
+)
+
+// secretHeaders carry signatures or tokens.
+var secretHeaders = []string{
+	"Authorization",
+	"X-Auth-Token",
+	"X-Subject-Token",
+	"X-Security-Token",
+}
+
+// secretFields are the JSON fields whose values are redacted, normalized by
+// normalizeField.
+var secretFields = map[string]bool{
+	"password":      true,
+	"adminpass":     true,
+	"secret":        true,
+	"secretkey":     true,
+	"access":        true,
+	"accesskey":     true,
+	"securitytoken": true,
+	"token":         true,
+	"privatekey":    true,
+}
+
+// wireLogTransport logs every request and response with secrets redacted.
+type wireLogTransport struct {
+	next http.RoundTripper
+	log  logging.Logger
+}
+
+func newWireLogTransport(next http.RoundTripper, log logging.Logger) *wireLogTransport {
+	return &wireLogTransport{next: next, log: log}
+}
+
+func (t *wireLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody := requestBody(req)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	kv := []any{
+		"method", req.Method,
+		"url", req.URL.Redacted(),
+		"requestHeaders", redactHeaders(req.Header),
+		"requestBody", redactBody(reqBody),
+		"duration", duration.String(),
+	}
+	if err != nil {
+		t.log.Info("OTC API request failed", append(kv, "error", err.Error())...)
+		return nil, err
+	}
+
+	kv = append(kv,
+		"status", resp.StatusCode,
+		"responseHeaders", redactHeaders(resp.Header),
+		"responseBody", redactBody(responseBody(resp)),
+	)
+	t.log.Info("OTC API request", kv...)
+	return resp, nil
+}
+
+// requestBody returns the body of the supplied request without consuming it.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil
+		}
+		defer rc.Close() //nolint:errcheck // Nothing to do about it.
+		b, _ := io.ReadAll(rc)
+		return b
+	}
+	b, _ := io.ReadAll(req.Body)
+	req.Body.Close() //nolint:errcheck // Nothing to do about it.
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b
+}
+
+// responseBody reads the body of the supplied response and replaces it, so
+// that the caller can read it again.
+func responseBody(resp *http.Response) []byte {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redactHeaders returns the supplied headers with signatures and tokens
+// redacted. AK/SK signatures keep their algorithm, e.g. SDK-HMAC-SHA256.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		out[k] = h.Get(k)
+	}
+	for _, k := range secretHeaders {
+		v := h.Get(k)
+		if v == "" {
+			continue
+		}
+		if scheme, _, ok := strings.Cut(v, " "); ok && k == "Authorization" {
+			out[k] = scheme + " " + redacted
+			continue
+		}
+		out[k] = redacted
+	}
+	return out
+}
+
+// redactBody returns the supplied body with the values of secret JSON fields
+// redacted. Bodies that are not JSON are returned as they are.
+func redactBody(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	var v any
+	if json.Unmarshal(b, &v) == nil {
+		if r, err := json.Marshal(redactValue("", v)); err == nil {
+			b = r
+		}
+	}
+
+	if len(b) > maxLoggedBody {
+		return string(b[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(b)
+}
+
+// redactValue redacts the secret fields of the supplied JSON value, which is
+// the value of the supplied field.
+func redactValue(field string, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			// A token authentication nests the token as {"token":{"id":...}}.
+			if normalizeField(field) == "token" && k == "id" {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(k, e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(field, e)
+		}
+		return v
+	case string:
+		if secretFields[normalizeField(field)] {
+			return redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// normalizeField normalizes the spellings of a JSON field, e.g. secret_key,
+// secretKey and secret-key.
+func normalizeField(f string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(f))
+}
@@ -0,0 +1,137 @@
+package clients
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+// logger records the key value pairs of the messages it receives.
+type logger struct {
+	entries []map[string]any
+}
+
+func (l *logger) Info(_ string, kv ...any) {
+	e := map[string]any{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		e[kv[i].(string)] = kv[i+1]
+	}
+	l.entries = append(l.entries, e)
+}
+
+func (l *logger) Debug(msg string, kv ...any) {
+	l.Info(msg, kv...)
+}
+
+func (l *logger) WithValues(...any) logging.Logger {
+	return l
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		body   string
+		want   string
+	}{
+		"Password": {
+			reason: "Should redact passwords but keep the structure of a password authentication",
+			body:   `{"auth":{"identity":{"methods":["password"],"password":{"user":{"name":"admin","password":"s3cret"}}}}}`,
+			want:   `{"auth":{"identity":{"methods":["password"],"password":{"user":{"name":"admin","password":"REDACTED"}}}}}`,
+		},
+		"Token": {
+			reason: "Should redact the ID of a token authentication",
+			body:   `{"auth":{"identity":{"methods":["token"],"token":{"id":"abc"}}}}`,
+			want:   `{"auth":{"identity":{"methods":["token"],"token":{"id":"REDACTED"}}}}`,
+		},
+		"Credentials": {
+			reason: "Should redact temporary credentials of any spelling",
+			body:   `{"credential":{"access":"AK","secret":"SK","securitytoken":"ST","expires_at":"2026-01-01T00:00:00Z"}}`,
+			want:   `{"credential":{"access":"REDACTED","expires_at":"2026-01-01T00:00:00Z","secret":"REDACTED","securitytoken":"REDACTED"}}`,
+		},
+		"Payload": {
+			reason: "Should not touch payloads without secrets",
+			body:   `{"security_group_rule":{"direction":"ingress","protocol":"tcp"}}`,
+			want:   `{"security_group_rule":{"direction":"ingress","protocol":"tcp"}}`,
+		},
+		"NotJSON": {
+			reason: "Should return bodies that are not JSON as they are",
+			body:   `<html>bad gateway</html>`,
+			want:   `<html>bad gateway</html>`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, redactBody([]byte(tc.body))); diff != "" {
+				t.Errorf("\n%s\nredactBody(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWireLogTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("X-Subject-Token", "token")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	log := &logger{}
+	hc := &http.Client{Transport: newWireLogTransport(http.DefaultTransport, log)}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/v3/auth/tokens", strings.NewReader(`{"password":"s3cret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "SDK-HMAC-SHA256 Access=AK, SignedHeaders=host, Signature=abc")
+	req.Header.Set("X-Auth-Token", "token")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+
+	// The caller must still be able to read the logged response body.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(`{"password":"s3cret"}`, string(body)); diff != "" {
+		t.Errorf("resp.Body: -want, +got:\n%s\n", diff)
+	}
+
+	if len(log.entries) != 1 {
+		t.Fatalf("want 1 log entry, got %d", len(log.entries))
+	}
+	e := log.entries[0]
+	got := map[string]any{
+		"status":        e["status"],
+		"requestBody":   e["requestBody"],
+		"responseBody":  e["responseBody"],
+		"authorization": e["requestHeaders"].(map[string]string)["Authorization"],
+		"authToken":     e["requestHeaders"].(map[string]string)["X-Auth-Token"],
+		"subjectToken":  e["responseHeaders"].(map[string]string)["X-Subject-Token"],
+	}
+	want := map[string]any{
+		"status":        http.StatusCreated,
+		"requestBody":   `{"password":"REDACTED"}`,
+		"responseBody":  `{"password":"REDACTED"}`,
+		"authorization": "SDK-HMAC-SHA256 REDACTED",
+		"authToken":     redacted,
+		"subjectToken":  redacted,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RoundTrip(...): -want log entry, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,141 @@
+// Package adopt finds the external resource of a managed resource whose
+// external name was lost, e.g. because the provider stopped after creating the
+// external resource but before persisting its ID in the external-name
+// annotation. Adopting it instead of creating another one makes Create
+// idempotent.
+package adopt
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TagUID is the key of the tag that the provider stamps on the external
+// resources it creates. Its value is the UID of the managed resource.
+const TagUID = "crossplane-uid"
+
+const (
+	errAmbiguous = "cannot adopt an existing external resource, found several that match the managed resource"
+	errUntagged  = "cannot adopt an existing external resource that matches the managed resource but carries neither its UID tag nor its desired tags"
+)
+
+// A Candidate is an external resource that matches the desired state of a
+// managed resource, e.g. by name and CIDR.
+type Candidate struct {
+	// ID of the external resource.
+	ID string
+
+	// Tags of the external resource.
+	Tags []tags.ResourceTag
+}
+
+// Tag returns the tag that marks an external resource as created for the
+// supplied managed resource, or nil if the managed resource has no UID yet.
+func Tag(mg metav1.Object) []tags.ResourceTag {
+	if mg.GetUID() == "" {
+		return nil
+	}
+	return []tags.ResourceTag{{Key: TagUID, Value: string(mg.GetUID())}}
+}
+
+// Tagged returns true if the supplied tags contain the TagUID tag of the
+// supplied managed resource.
+func Tagged(mg metav1.Object, ts []tags.ResourceTag) bool {
+	uid, ok := uidOf(ts)
+	return ok && uid == string(mg.GetUID())
+}
+
+// Select returns the ID of the candidate to adopt for the supplied managed
+// resource, or an empty string if there is none. Only candidates that are
+// tagged with the UID of the managed resource, or that carry all of its
+// supplied desired tags, are adopted. Candidates tagged with the UID take
+// precedence. Candidates tagged with the UID of another managed resource
+// belong to it and are never adopted. Select returns an error that lists the
+// candidates instead of guessing if more than one of them could be adopted,
+// or if the only candidates carry none of these tags, since they may belong to
+// somebody else.
+func Select(mg metav1.Object, desired map[string]string, cs []Candidate) (string, error) {
+	return selectCandidate(mg, desired, cs, false)
+}
+
+// SelectUntagged is like Select for external resources that cannot be tagged
+// on creation. A provider that stops between creating such an external
+// resource and tagging it leaves it without any tags, so SelectUntagged also
+// adopts the only candidate without any tags if no candidate carries the UID
+// tag or the desired tags. The caller has to match candidates closely, e.g. by
+// name and CIDR.
+func SelectUntagged(mg metav1.Object, desired map[string]string, cs []Candidate) (string, error) {
+	return selectCandidate(mg, desired, cs, true)
+}
+
+func selectCandidate(mg metav1.Object, desired map[string]string, cs []Candidate, bareOK bool) (string, error) {
+	var owned, labelled, untagged, bare []string
+	for _, c := range cs {
+		uid, ok := uidOf(c.Tags)
+		switch {
+		case ok && mg.GetUID() != "" && uid == string(mg.GetUID()):
+			owned = append(owned, c.ID)
+		case ok:
+			// Belongs to another managed resource.
+		case len(desired) > 0 && superset(c.Tags, desired):
+			labelled = append(labelled, c.ID)
+		default:
+			untagged = append(untagged, c.ID)
+			if len(c.Tags) == 0 {
+				bare = append(bare, c.ID)
+			}
+		}
+	}
+
+	ids := owned
+	if len(ids) == 0 {
+		ids = labelled
+	}
+	if len(ids) == 0 && bareOK {
+		ids = bare
+	}
+	switch len(ids) {
+	case 0:
+		if len(untagged) > 0 {
+			sort.Strings(untagged)
+			return "", errors.Errorf("%s: %s, set the %s annotation to its ID to adopt it, or delete it",
+				errUntagged, strings.Join(untagged, ", "), meta.AnnotationKeyExternalName)
+		}
+		return "", nil
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", errors.Errorf("%s: %s, set the %s annotation to the ID of the one to adopt",
+			errAmbiguous, strings.Join(ids, ", "), meta.AnnotationKeyExternalName)
+	}
+}
+
+// superset returns true if the supplied tags contain all of the supplied
+// desired tags.
+func superset(ts []tags.ResourceTag, desired map[string]string) bool {
+	have := make(map[string]string, len(ts))
+	for _, t := range ts {
+		have[t.Key] = t.Value
+	}
+	for k, v := range desired {
+		if w, ok := have[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+func uidOf(ts []tags.ResourceTag) (string, bool) {
+	for _, t := range ts {
+		if t.Key == TagUID {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+package adopt
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelect(t *testing.T) {
+	mg := &metav1.ObjectMeta{UID: "uid-1"}
+	owned := []tags.ResourceTag{{Key: TagUID, Value: "uid-1"}}
+	foreign := []tags.ResourceTag{{Key: TagUID, Value: "uid-2"}}
+	desired := map[string]string{"team": "network"}
+	labelled := []tags.ResourceTag{{Key: "team", Value: "network"}, {Key: "env", Value: "prod"}}
+	mislabelled := []tags.ResourceTag{{Key: "team", Value: "storage"}}
+
+	type want struct {
+		id  string
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		untagged bool
+		desired  map[string]string
+		cs       []Candidate
+		want     want
+	}{
+		"None": {
+			reason: "Should adopt nothing if there are no candidates",
+		},
+		"Untagged": {
+			reason: "Should report a single untagged candidate instead of adopting it",
+			cs:     []Candidate{{ID: "a"}},
+			want:   want{err: true},
+		},
+		"UntaggedWithDesiredTags": {
+			reason:  "Should report a candidate that lacks the desired tags instead of adopting it",
+			desired: desired,
+			cs:      []Candidate{{ID: "a"}, {ID: "b", Tags: mislabelled}},
+			want:    want{err: true},
+		},
+		"DesiredTags": {
+			reason:  "Should adopt a single candidate that carries all desired tags",
+			desired: desired,
+			cs:      []Candidate{{ID: "a", Tags: labelled}, {ID: "b", Tags: mislabelled}},
+			want:    want{id: "a"},
+		},
+		"Owned": {
+			reason:  "Should prefer the candidate tagged with the UID of the managed resource",
+			desired: desired,
+			cs:      []Candidate{{ID: "a", Tags: labelled}, {ID: "b", Tags: owned}, {ID: "c"}},
+			want:    want{id: "b"},
+		},
+		"Foreign": {
+			reason: "Should never adopt candidates of other managed resources",
+			cs:     []Candidate{{ID: "a", Tags: foreign}},
+		},
+		"AmbiguousDesiredTags": {
+			reason:  "Should report several candidates that carry the desired tags instead of guessing",
+			desired: desired,
+			cs:      []Candidate{{ID: "a", Tags: labelled}, {ID: "b", Tags: labelled}, {ID: "c", Tags: foreign}},
+			want:    want{err: true},
+		},
+		"UntaggedOrphan": {
+			reason:   "Should adopt a single candidate without any tags if it cannot have been tagged on creation",
+			untagged: true,
+			desired:  desired,
+			cs:       []Candidate{{ID: "a"}, {ID: "b", Tags: foreign}},
+			want:     want{id: "a"},
+		},
+		"UntaggedOrphanMislabelled": {
+			reason:   "Should report a candidate that carries tags other than the desired ones even if it cannot have been tagged on creation",
+			untagged: true,
+			desired:  desired,
+			cs:       []Candidate{{ID: "a", Tags: mislabelled}},
+			want:     want{err: true},
+		},
+		"UntaggedOrphanOwned": {
+			reason:   "Should prefer the candidate tagged with the UID of the managed resource over one without any tags",
+			untagged: true,
+			cs:       []Candidate{{ID: "a"}, {ID: "b", Tags: owned}},
+			want:     want{id: "b"},
+		},
+		"AmbiguousUntaggedOrphans": {
+			reason:   "Should report several candidates without any tags instead of guessing",
+			untagged: true,
+			cs:       []Candidate{{ID: "a"}, {ID: "b"}},
+			want:     want{err: true},
+		},
+		"AmbiguousOwned": {
+			reason: "Should report several candidates tagged with the UID of the managed resource",
+			cs:     []Candidate{{ID: "a", Tags: owned}, {ID: "b", Tags: owned}},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sel := Select
+			if tc.untagged {
+				sel = SelectUntagged
+			}
+			id, err := sel(mg, tc.desired, tc.cs)
+			got := want{id: id, err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nSelect(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Package audit records an event for every mutation of an external resource,
+// including the OTC request IDs that identify it in the OTC API logs.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+// Annotations of the recorded events.
+const (
+	AnnotationOperation = "opentelekomcloud.crossplane.io/operation"
+	AnnotationRequestID = "opentelekomcloud.crossplane.io/request-id"
+	AnnotationErrorCode = "opentelekomcloud.crossplane.io/error-code"
+)
+
+// Operations on external resources.
+const (
+	OperationCreate = "Create"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// NewConnector returns a connector whose clients record an event after every
+// Create, Update and Delete call of the supplied connector's clients.
+func NewConnector(c managed.ExternalConnector, r event.Recorder) managed.ExternalConnector {
+	return &connector{next: c, record: r}
+}
+
+type connector struct {
+	next   managed.ExternalConnector
+	record event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ctx, log := clients.WithRequestLog(ctx)
+	ec, err := c.next.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connector: c, next: ec, log: log}, nil
+}
+
+type external struct {
+	*connector
+	next managed.ExternalClient
+	log  *clients.RequestLog
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return e.next.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	n := e.log.Len()
+	c, err := e.next.Create(ctx, mg)
+	e.event(mg, OperationCreate, n, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	n := e.log.Len()
+	u, err := e.next.Update(ctx, mg)
+	e.event(mg, OperationUpdate, n, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	n := e.log.Len()
+	d, err := e.next.Delete(ctx, mg)
+	e.event(mg, OperationDelete, n, err)
+	return d, err
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
+
+// event records the outcome of the supplied operation, which sent the
+// requests logged after the first n ones.
+func (e *external) event(mg resource.Managed, op string, n int, err error) {
+	ids := e.log.Since(n)
+
+	if err == nil {
+		msg := op + " succeeded"
+		if len(ids) > 0 {
+			msg += fmt.Sprintf(" (request ID %s)", strings.Join(ids, ", "))
+		}
+		e.record.Event(mg, event.Normal(event.Reason(op+"Succeeded"), msg,
+			AnnotationOperation, op,
+			AnnotationRequestID, strings.Join(ids, ","),
+		))
+		return
+	}
+
+	code := ""
+	if oe, ok := clients.ParseError(err); ok {
+		code = oe.Code
+		// Failed requests are not logged, their ID is part of the error.
+		if oe.RequestID != "" {
+			ids = append(ids, oe.RequestID)
+		}
+		err = oe
+	}
+	e.record.Event(mg, event.Warning(event.Reason(op+"Failed"), errors.Wrapf(err, "%s failed", op),
+		AnnotationOperation, op,
+		AnnotationRequestID, strings.Join(ids, ","),
+		AnnotationErrorCode, code,
+	))
+}
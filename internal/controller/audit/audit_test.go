@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// recorder records the events it receives.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   event.Event
+	}{
+		"Success": {
+			reason: "Should record successful mutations",
+			want: event.Event{
+				Type:    event.TypeNormal,
+				Reason:  "DeleteSucceeded",
+				Message: "Delete succeeded",
+				Annotations: map[string]string{
+					AnnotationOperation: OperationDelete,
+					AnnotationRequestID: "",
+				},
+			},
+		},
+		"APIError": {
+			reason: "Should record the error code and the request ID of failed mutations",
+			err: errors.Wrap(golangsdk.ErrDefault409{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+				Actual: http.StatusConflict,
+				Body:   []byte(`{"error_code":"VPC.0005","error_msg":"VPC is in use","request_id":"req-1"}`),
+			}}, "cannot delete VPC"),
+			want: event.Event{
+				Type:    event.TypeWarning,
+				Reason:  "DeleteFailed",
+				Message: "Delete failed: VPC is in use (HTTP 409, error code VPC.0005, request ID req-1)",
+				Annotations: map[string]string{
+					AnnotationOperation: OperationDelete,
+					AnnotationRequestID: "req-1",
+					AnnotationErrorCode: "VPC.0005",
+				},
+			},
+		},
+		"OtherError": {
+			reason: "Should record failed mutations without an OTC error response",
+			err:    errors.New("connection reset"),
+			want: event.Event{
+				Type:    event.TypeWarning,
+				Reason:  "DeleteFailed",
+				Message: "Delete failed: connection reset",
+				Annotations: map[string]string{
+					AnnotationOperation: OperationDelete,
+					AnnotationRequestID: "",
+					AnnotationErrorCode: "",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					DeleteFn: func(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+						return managed.ExternalDelete{}, tc.err
+					},
+				}, nil
+			}), rec)
+
+			ec, err := c.Connect(context.Background(), &fake.Managed{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = ec.Delete(context.Background(), &fake.Managed{})
+
+			if diff := cmp.Diff([]event.Event{tc.want}, rec.events); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want events, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
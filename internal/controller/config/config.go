@@ -17,7 +17,6 @@ limitations under the License.
 package config
 
 import (
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/providerconfig"
@@ -25,18 +24,26 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
 )
 
-// Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	if err := setupNamespacedProviderConfig(mgr, o); err != nil {
-		return err
+// Setup adds controllers that reconcile ProviderConfigs by accounting for
+// their current usage and by validating their credentials.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	for _, setup := range []func(ctrl.Manager, options.Options) error{
+		setupNamespacedProviderConfig,
+		setupClusterProviderConfig,
+		setupNamespacedValidation,
+		setupClusterValidation,
+	} {
+		if err := setup(mgr, o); err != nil {
+			return err
+		}
 	}
-	return setupClusterProviderConfig(mgr, o)
+	return nil
 }
 
-func setupNamespacedProviderConfig(mgr ctrl.Manager, o controller.Options) error {
+func setupNamespacedProviderConfig(mgr ctrl.Manager, o options.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
 	of := resource.ProviderConfigKinds{
@@ -57,7 +64,7 @@ func setupNamespacedProviderConfig(mgr ctrl.Manager, o controller.Options) error
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
-func setupClusterProviderConfig(mgr ctrl.Manager, o controller.Options) error {
+func setupClusterProviderConfig(mgr ctrl.Manager, o options.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ClusterProviderConfigGroupKind)
 	of := resource.ProviderConfigKinds{
 		Config:    v1alpha1.ClusterProviderConfigGroupVersionKind,
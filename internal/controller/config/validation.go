@@ -0,0 +1,219 @@
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
+)
+
+const (
+	errGetConfig    = "cannot get provider config"
+	errListConfigs  = "cannot list provider configs"
+	errUpdateStatus = "cannot update provider config status"
+)
+
+// validationControllerName returns the name of the controller that validates
+// the provider configs of the supplied kind.
+func validationControllerName(kind string) string {
+	return "validation/" + strings.ToLower(kind)
+}
+
+func setupNamespacedValidation(mgr ctrl.Manager, o options.Options) error {
+	name := validationControllerName(v1alpha1.ProviderConfigGroupKind)
+
+	r := &validationReconciler{
+		kube:      mgr.GetClient(),
+		sessions:  o.Sessions,
+		log:       o.Logger.WithValues("controller", name),
+		interval:  o.ProviderConfigCheckInterval,
+		newConfig: func() resource.ProviderConfig { return &v1alpha1.ProviderConfig{} },
+		spec: func(pc resource.ProviderConfig) v1alpha1.ProviderConfigSpec {
+			return pc.(*v1alpha1.ProviderConfig).Spec
+		},
+		key: func(nn types.NamespacedName) string {
+			return clients.ProviderConfigKey(nn.Namespace, nn.Name)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(namespacedConfigsForSecret(r.kube, r.log))).
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
+}
+
+func setupClusterValidation(mgr ctrl.Manager, o options.Options) error {
+	name := validationControllerName(v1alpha1.ClusterProviderConfigGroupKind)
+
+	r := &validationReconciler{
+		kube:      mgr.GetClient(),
+		sessions:  o.Sessions,
+		log:       o.Logger.WithValues("controller", name),
+		interval:  o.ProviderConfigCheckInterval,
+		newConfig: func() resource.ProviderConfig { return &v1alpha1.ClusterProviderConfig{} },
+		spec: func(pc resource.ProviderConfig) v1alpha1.ProviderConfigSpec {
+			return pc.(*v1alpha1.ClusterProviderConfig).Spec
+		},
+		key: func(nn types.NamespacedName) string {
+			return clients.ClusterProviderConfigKey(nn.Name)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ClusterProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(clusterConfigsForSecret(r.kube, r.log))).
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
+}
+
+// namespacedConfigsForSecret returns a function that maps a secret to the
+// requests of the ProviderConfigs that reference it.
+func namespacedConfigsForSecret(kube client.Client, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, secret client.Object) []reconcile.Request {
+		l := &v1alpha1.ProviderConfigList{}
+		if err := kube.List(ctx, l); err != nil {
+			log.Debug(errListConfigs, "error", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, pc := range l.Items {
+			if referencesSecret(pc.Spec, secret) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pc.Namespace, Name: pc.Name}})
+			}
+		}
+		return reqs
+	}
+}
+
+// clusterConfigsForSecret returns a function that maps a secret to the
+// requests of the ClusterProviderConfigs that reference it.
+func clusterConfigsForSecret(kube client.Client, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, secret client.Object) []reconcile.Request {
+		l := &v1alpha1.ClusterProviderConfigList{}
+		if err := kube.List(ctx, l); err != nil {
+			log.Debug(errListConfigs, "error", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, pc := range l.Items {
+			if referencesSecret(pc.Spec, secret) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: pc.Name}})
+			}
+		}
+		return reqs
+	}
+}
+
+// referencesSecret returns true if the supplied spec reads its credentials
+// or transport settings from the supplied secret.
+func referencesSecret(spec v1alpha1.ProviderConfigSpec, secret client.Object) bool {
+	refs := []*xpv1.SecretReference{}
+	if spec.Credentials.Source == xpv1.CredentialsSourceSecret && spec.Credentials.SecretRef != nil {
+		refs = append(refs, &spec.Credentials.SecretRef.SecretReference)
+	}
+	if t := spec.Transport; t != nil {
+		if t.CABundleSecretRef != nil {
+			refs = append(refs, &t.CABundleSecretRef.SecretReference)
+		}
+		refs = append(refs, t.ClientCertificateSecretRef)
+	}
+
+	for _, ref := range refs {
+		if ref != nil && ref.Name == secret.GetName() && ref.Namespace == secret.GetNamespace() {
+			return true
+		}
+	}
+	return false
+}
+
+// sessions provides the clients of provider configs. It is implemented by
+// the shared clients.Cache.
+type sessions interface {
+	GetClient(ctx context.Context, key string, spec v1alpha1.ProviderConfigSpec) (*clients.Client, error)
+	Evict(key string)
+}
+
+// validationReconciler checks that a provider config can authenticate and
+// reach its project, and reports the result as the Ready condition of the
+// provider config. Once the provider config is gone its cached session is
+// evicted.
+type validationReconciler struct {
+	kube      client.Client
+	sessions  sessions
+	log       logging.Logger
+	interval  time.Duration
+	newConfig func() resource.ProviderConfig
+	spec      func(resource.ProviderConfig) v1alpha1.ProviderConfigSpec
+	key       func(types.NamespacedName) string
+}
+
+func (r *validationReconciler) Reconcile(
+	ctx context.Context,
+	req reconcile.Request,
+) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := r.newConfig()
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.Debug("Evicting session of deleted provider config")
+			r.sessions.Evict(r.key(req.NamespacedName))
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, errGetConfig)
+	}
+
+	if meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	cond := xpv1.Available()
+	if err := r.validate(ctx, req.NamespacedName, pc); err != nil {
+		log.Debug("Provider config is unavailable", "error", err)
+		cond = xpv1.Unavailable().WithMessage(err.Error())
+	}
+
+	pc.SetConditions(cond)
+	if err := r.kube.Status().Update(ctx, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+	}
+
+	return reconcile.Result{RequeueAfter: r.interval}, nil
+}
+
+func (r *validationReconciler) validate(
+	ctx context.Context,
+	nn types.NamespacedName,
+	pc resource.ProviderConfig,
+) error {
+	c, err := r.sessions.GetClient(ctx, r.key(nn), r.spec(pc))
+	if err != nil {
+		return err
+	}
+	return c.CheckProject()
+}
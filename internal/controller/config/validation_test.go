@@ -0,0 +1,303 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+// fakeSessions returns the supplied client or error and records evictions.
+type fakeSessions struct {
+	client  *clients.Client
+	err     error
+	evicted []string
+}
+
+func (s *fakeSessions) GetClient(_ context.Context, _ string, _ v1alpha1.ProviderConfigSpec) (*clients.Client, error) {
+	return s.client, s.err
+}
+
+func (s *fakeSessions) Evict(key string) {
+	s.evicted = append(s.evicted, key)
+}
+
+// projectClient returns a client whose network endpoint is served by the
+// supplied handler.
+func projectClient(t *testing.T, handler http.HandlerFunc) *clients.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	pc := &golangsdk.ProviderClient{TokenID: "token", ProjectID: "project"}
+	pc.EndpointLocator = func(golangsdk.EndpointOpts) (string, error) {
+		return srv.URL + "/", nil
+	}
+	return &clients.Client{ProviderClient: pc, Region: "eu-de"}
+}
+
+func TestValidationReconcile(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "default", Name: "pc"}
+	key := clients.ProviderConfigKey(nn.Namespace, nn.Name)
+
+	reachable := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/project/vpcs" {
+			t.Errorf("project check: want path /v1/project/vpcs, got %s", r.URL.Path)
+		}
+		w.Header().Add("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"vpcs":[]}`))
+	}
+	forbidden := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	type args struct {
+		get      error
+		sessions func(t *testing.T) *fakeSessions
+	}
+
+	type want struct {
+		result    reconcile.Result
+		status    corev1.ConditionStatus
+		reason    xpv1.ConditionReason
+		message   string
+		evicted   []string
+		updated   bool
+		errPrefix string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Available": {
+			reason: "Should report a provider config that can reach its project as available",
+			args: args{
+				sessions: func(t *testing.T) *fakeSessions {
+					return &fakeSessions{client: projectClient(t, reachable)}
+				},
+			},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: time.Minute},
+				status:  corev1.ConditionTrue,
+				reason:  xpv1.ReasonAvailable,
+				updated: true,
+			},
+		},
+		"LoginFailed": {
+			reason: "Should report a provider config that cannot authenticate as unavailable",
+			args: args{
+				sessions: func(*testing.T) *fakeSessions {
+					return &fakeSessions{err: errors.New("cannot authenticate with Open Telekom Cloud")}
+				},
+			},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: time.Minute},
+				status:  corev1.ConditionFalse,
+				reason:  xpv1.ReasonUnavailable,
+				message: "cannot authenticate with Open Telekom Cloud",
+				updated: true,
+			},
+		},
+		"ProjectUnreachable": {
+			reason: "Should report a provider config that cannot reach its project as unavailable",
+			args: args{
+				sessions: func(t *testing.T) *fakeSessions {
+					return &fakeSessions{client: projectClient(t, forbidden)}
+				},
+			},
+			want: want{
+				result:  reconcile.Result{RequeueAfter: time.Minute},
+				status:  corev1.ConditionFalse,
+				reason:  xpv1.ReasonUnavailable,
+				message: "cannot reach project project in region eu-de",
+				updated: true,
+			},
+		},
+		"NotFound": {
+			reason: "Should evict the session of a deleted provider config",
+			args: args{
+				get: kerrors.NewNotFound(schema.GroupResource{}, nn.Name),
+				sessions: func(*testing.T) *fakeSessions {
+					return &fakeSessions{}
+				},
+			},
+			want: want{
+				evicted: []string{key},
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if the provider config cannot be read",
+			args: args{
+				get: errors.New("boom"),
+				sessions: func(*testing.T) *fakeSessions {
+					return &fakeSessions{}
+				},
+			},
+			want: want{
+				errPrefix: errGetConfig,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := tc.args.sessions(t)
+
+			var updated *v1alpha1.ProviderConfig
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(tc.args.get),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					updated = obj.(*v1alpha1.ProviderConfig)
+					return nil
+				},
+			}
+
+			r := &validationReconciler{
+				kube:      kube,
+				sessions:  s,
+				log:       logging.NewNopLogger(),
+				interval:  time.Minute,
+				newConfig: func() resource.ProviderConfig { return &v1alpha1.ProviderConfig{} },
+				spec: func(pc resource.ProviderConfig) v1alpha1.ProviderConfigSpec {
+					return pc.(*v1alpha1.ProviderConfig).Spec
+				},
+				key: func(nn types.NamespacedName) string {
+					return clients.ProviderConfigKey(nn.Namespace, nn.Name)
+				},
+			}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+
+			got := want{result: result, evicted: s.evicted, updated: updated != nil}
+			if err != nil {
+				got.errPrefix = tc.want.errPrefix
+				if !strings.HasPrefix(err.Error(), tc.want.errPrefix) {
+					t.Errorf("\n%s\nr.Reconcile(...): want error with prefix %q, got %q\n", tc.reason, tc.want.errPrefix, err)
+				}
+			}
+			if updated != nil {
+				c := updated.GetCondition(xpv1.TypeReady)
+				got.status, got.reason = c.Status, c.Reason
+				got.message = tc.want.message
+				if !strings.Contains(c.Message, tc.want.message) {
+					t.Errorf("\n%s\nr.Reconcile(...): want message containing %q, got %q\n", tc.reason, tc.want.message, c.Message)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigsForSecret(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "creds"}}
+
+	credentials := func(namespace, name string) v1alpha1.ProviderConfigSpec {
+		return v1alpha1.ProviderConfigSpec{
+			Credentials: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: namespace, Name: name},
+						Key:             "credentials",
+					},
+				},
+			},
+		}
+	}
+	caBundle := v1alpha1.ProviderConfigSpec{
+		Transport: &v1alpha1.Transport{
+			CABundleSecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "creds"},
+				Key:             "ca.crt",
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mapFn  func(client.Client) func(context.Context, client.Object) []reconcile.Request
+		want   []reconcile.Request
+	}{
+		"Namespaced": {
+			reason: "Should enqueue the ProviderConfigs that read their credentials or CA bundle from the secret",
+			kube: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*v1alpha1.ProviderConfigList).Items = []v1alpha1.ProviderConfig{
+						{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "creds"}, Spec: credentials("crossplane-system", "creds")},
+						{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "other"}, Spec: credentials("crossplane-system", "other")},
+						{ObjectMeta: metav1.ObjectMeta{Namespace: "team-c", Name: "ca"}, Spec: caBundle},
+					}
+					return nil
+				}),
+			},
+			mapFn: func(kube client.Client) func(context.Context, client.Object) []reconcile.Request {
+				return namespacedConfigsForSecret(kube, logging.NewNopLogger())
+			},
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "creds"}},
+				{NamespacedName: types.NamespacedName{Namespace: "team-c", Name: "ca"}},
+			},
+		},
+		"Cluster": {
+			reason: "Should enqueue the ClusterProviderConfigs that read their credentials from the secret",
+			kube: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*v1alpha1.ClusterProviderConfigList).Items = []v1alpha1.ClusterProviderConfig{
+						{ObjectMeta: metav1.ObjectMeta{Name: "default"}, Spec: credentials("crossplane-system", "creds")},
+						{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: credentials("default", "creds")},
+					}
+					return nil
+				}),
+			},
+			mapFn: func(kube client.Client) func(context.Context, client.Object) []reconcile.Request {
+				return clusterConfigsForSecret(kube, logging.NewNopLogger())
+			},
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "default"}},
+			},
+		},
+		"ListFailed": {
+			reason: "Should enqueue nothing if the provider configs cannot be listed",
+			kube: &test.MockClient{
+				MockList: test.NewMockListFn(errors.New("boom")),
+			},
+			mapFn: func(kube client.Client) func(context.Context, client.Object) []reconcile.Request {
+				return namespacedConfigsForSecret(kube, logging.NewNopLogger())
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.mapFn(tc.kube)(context.Background(), secret)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nconfigsForSecret(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package dependency waits for the dependents of external resources to be
+// deleted before deleting the external resources themselves.
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+const (
+	// TypeWaitingForDependents managed resources are being deleted, but their
+	// external resource still has dependents that block its deletion.
+	TypeWaitingForDependents xpv1.ConditionType = "WaitingForDependents"
+
+	// ReasonDependentsExist indicates that the deletion of an external
+	// resource was refused because other resources still depend on it.
+	ReasonDependentsExist xpv1.ConditionReason = "DependentsExist"
+
+	// ReasonDependentsDeleted indicates that the dependents of an external
+	// resource no longer block its deletion.
+	ReasonDependentsDeleted xpv1.ConditionReason = "DependentsDeleted"
+)
+
+// Blocked returns a condition that indicates that the deletion of an external
+// resource is blocked by dependents of the supplied kinds.
+func Blocked(err *clients.Error, kinds ...string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWaitingForDependents,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependentsExist,
+		Message:            fmt.Sprintf("waiting for dependent %s to be deleted: %s", strings.Join(kinds, ", "), err),
+	}
+}
+
+// Unblocked returns a condition that indicates that the deletion of an
+// external resource is no longer blocked by dependents.
+func Unblocked() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWaitingForDependents,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependentsDeleted,
+	}
+}
+
+// Wait returns the supplied result of a Delete call. If the OTC API refused
+// the deletion because other resources still depend on the external resource,
+// it sets the WaitingForDependents condition, naming the supplied kinds of
+// resources that may depend on it, and the managed resource is not retried
+// before the interval of NewReconciler.
+func Wait(mg resource.Conditioned, err error, kinds ...string) error {
+	oe, ok := clients.ParseError(err)
+	if !ok || !oe.DependencyConflict() {
+		if mg.GetCondition(TypeWaitingForDependents).Status == corev1.ConditionTrue {
+			mg.SetConditions(Unblocked())
+		}
+		return err
+	}
+	mg.SetConditions(Blocked(oe, kinds...))
+	return errors.Wrapf(err, "waiting for dependent %s to be deleted", strings.Join(kinds, ", "))
+}
+
+// NewReconciler returns a reconciler that requeues managed resources of the
+// supplied kind after the supplied interval, instead of with an exponential
+// backoff, while the deletion of their external resource waits for
+// dependents.
+func NewReconciler(kube client.Client, of resource.ManagedKind, r reconcile.Reconciler, interval time.Duration) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		res, err := r.Reconcile(ctx, req)
+		if err != nil || res.RequeueAfter > 0 {
+			return res, err
+		}
+		if waiting(ctx, kube, of, req) {
+			return reconcile.Result{RequeueAfter: interval}, nil
+		}
+		return res, nil
+	})
+}
+
+// waiting returns true if the requested managed resource is being deleted
+// and waits for dependents of its external resource.
+func waiting(ctx context.Context, kube client.Client, of resource.ManagedKind, req reconcile.Request) bool {
+	obj, err := kube.Scheme().New(schema.GroupVersionKind(of))
+	if err != nil {
+		return false
+	}
+	mg, ok := obj.(resource.Managed)
+	if !ok || kube.Get(ctx, req.NamespacedName, mg) != nil || !meta.WasDeleted(mg) {
+		return false
+	}
+	return mg.GetCondition(TypeWaitingForDependents).Status == corev1.ConditionTrue
+}
@@ -0,0 +1,114 @@
+package dependency
+
+import (
+	"net/http"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+func TestWait(t *testing.T) {
+	errInUse := golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+		Actual: http.StatusBadRequest,
+		Body:   []byte(`{"code":"VPC.0005","message":"Delete vpc failed, because there are subnets in the vpc."}`),
+	}}
+	inUse, _ := clients.ParseError(errInUse)
+
+	type args struct {
+		c   []xpv1.Condition
+		err error
+	}
+
+	type want struct {
+		err    bool
+		status corev1.ConditionStatus
+		reason xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"DependentsExist": {
+			reason: "Should set the WaitingForDependents condition for dependency conflicts",
+			args: args{
+				err: errInUse,
+			},
+			want: want{
+				err:    true,
+				status: corev1.ConditionTrue,
+				reason: ReasonDependentsExist,
+			},
+		},
+		"InUse": {
+			reason: "Should recognize dependency conflicts reported as bad request",
+			args: args{
+				err: golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+					Actual: http.StatusBadRequest,
+					Body:   []byte(`{"NeutronError":{"type":"SubnetInUse","message":"Unable to complete operation on subnet. One or more ports have an IP allocation from this subnet."}}`),
+				}},
+			},
+			want: want{
+				err:    true,
+				status: corev1.ConditionTrue,
+				reason: ReasonDependentsExist,
+			},
+		},
+		"OtherConflict": {
+			reason: "Should not set the condition for conflicts other than dependents",
+			args: args{
+				err: golangsdk.ErrDefault409{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+					Actual: http.StatusConflict,
+					Body:   []byte(`{"NeutronError":{"type":"IpAddressAlreadyAllocated","message":"IP address 192.168.0.10 already allocated in subnet."}}`),
+				}},
+			},
+			want: want{
+				err:    true,
+				status: corev1.ConditionUnknown,
+			},
+		},
+		"OtherError": {
+			reason: "Should not set the condition for other errors",
+			args: args{
+				err: errors.New("connection reset"),
+			},
+			want: want{
+				err:    true,
+				status: corev1.ConditionUnknown,
+			},
+		},
+		"Deleted": {
+			reason: "Should resolve the condition once the deletion succeeds",
+			args: args{
+				c: []xpv1.Condition{Blocked(inUse, "subnets")},
+			},
+			want: want{
+				status: corev1.ConditionFalse,
+				reason: ReasonDependentsDeleted,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.args.c...)
+
+			err := Wait(mg, tc.args.err, "subnets")
+
+			cond := mg.GetCondition(TypeWaitingForDependents)
+			got := want{err: err != nil, status: cond.Status, reason: cond.Reason}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nWait(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
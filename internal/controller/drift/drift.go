@@ -0,0 +1,215 @@
+// Package drift reports which fields of an external resource differ from the
+// desired state of its managed resource.
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TypeDrifted managed resources have an external resource that differs
+	// from their desired state. The message lists the differing fields.
+	TypeDrifted xpv1.ConditionType = "Drifted"
+
+	// ReasonFieldsDiffer indicates that fields of the external resource
+	// differ from the desired state.
+	ReasonFieldsDiffer xpv1.ConditionReason = "FieldsDiffer"
+
+	// ReasonInSync indicates that the external resource matches the desired
+	// state.
+	ReasonInSync xpv1.ConditionReason = "InSync"
+
+	// ReasonDriftDetected is the reason of the event recorded when drift is
+	// detected.
+	ReasonDriftDetected event.Reason = "DriftDetected"
+)
+
+const errImmutable = "cannot update immutable fields in place, the resource has to be recreated"
+
+// A Field of an external resource that differs from the desired state.
+type Field struct {
+	// Path of the field in the managed resource, e.g. spec.forProvider.cidr.
+	Path string
+
+	// Desired value of the field.
+	Desired any
+
+	// Observed value of the field.
+	Observed any
+
+	// Immutable fields cannot be updated in place.
+	Immutable bool
+}
+
+func (f Field) String() string {
+	s := fmt.Sprintf("%s: desired %s, observed %s", f.Path, format(f.Desired), format(f.Observed))
+	if f.Immutable {
+		s += " (immutable)"
+	}
+	return s
+}
+
+// A Diff lists the fields of an external resource that differ from the
+// desired state.
+type Diff []Field
+
+// Compare adds the supplied field to the diff if its desired and observed
+// values differ. Values of any type are compared deeply, so slices and maps
+// are supported. Nil and empty slices and maps are equal.
+func (d *Diff) Compare(path string, desired, observed any) {
+	if !equal(desired, observed) {
+		*d = append(*d, Field{Path: path, Desired: desired, Observed: observed})
+	}
+}
+
+// CompareImmutable adds the supplied immutable field to the diff if its
+// desired and observed values differ.
+func (d *Diff) CompareImmutable(path string, desired, observed any) {
+	if !equal(desired, observed) {
+		*d = append(*d, Field{Path: path, Desired: desired, Observed: observed, Immutable: true})
+	}
+}
+
+func equal(desired, observed any) bool {
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty())
+}
+
+// Empty returns true if no field differs.
+func (d Diff) Empty() bool {
+	return len(d) == 0
+}
+
+// Immutable returns the immutable fields of the diff.
+func (d Diff) Immutable() Diff {
+	var out Diff
+	for _, f := range d {
+		if f.Immutable {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (d Diff) String() string {
+	s := make([]string, len(d))
+	for i, f := range d {
+		s[i] = f.String()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Err returns an error that explains why the immutable fields of the diff
+// cannot be updated, or nil if none of them differs.
+func (d Diff) Err() error {
+	if im := d.Immutable(); !im.Empty() {
+		return errors.Errorf("%s: %s", errImmutable, im)
+	}
+	return nil
+}
+
+// Condition returns the Drifted condition for the supplied diff of the
+// supplied generation of a managed resource.
+func Condition(d Diff, generation int64) xpv1.Condition {
+	if d.Empty() {
+		return xpv1.Condition{
+			Type:               TypeDrifted,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonInSync,
+			ObservedGeneration: generation,
+		}
+	}
+	return xpv1.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFieldsDiffer,
+		Message:            d.String(),
+		ObservedGeneration: generation,
+	}
+}
+
+// ErrImmutable returns an error with the supplied message that lists the
+// drifted fields of the supplied managed resource. Controllers of resources
+// whose fields are all immutable return it from Update.
+func ErrImmutable(mg resource.Conditioned, msg string) error {
+	if c := mg.GetCondition(TypeDrifted); c.Status == corev1.ConditionTrue {
+		return errors.Errorf("%s: %s", msg, c.Message)
+	}
+	return errors.New(msg)
+}
+
+// format returns a readable representation of a field value. Strings are
+// quoted so that empty values are visible.
+func format(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// NewConnector returns a connector whose clients record an event whenever
+// Observe of the supplied connector's clients detects new drift, i.e. sets
+// the Drifted condition with fields that did not differ before.
+func NewConnector(c managed.ExternalConnector, r event.Recorder) managed.ExternalConnector {
+	return &connector{next: c, record: r}
+}
+
+type connector struct {
+	next   managed.ExternalConnector
+	record event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.next.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connector: c, next: ec}, nil
+}
+
+type external struct {
+	*connector
+	next managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	before := mg.GetCondition(TypeDrifted)
+	o, err := e.next.Observe(ctx, mg)
+	after := mg.GetCondition(TypeDrifted)
+
+	if after.Status == corev1.ConditionTrue &&
+		(before.Status != corev1.ConditionTrue || before.Message != after.Message) {
+		e.record.Event(mg, event.Normal(ReasonDriftDetected, after.Message))
+	}
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return e.next.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return e.next.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return e.next.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
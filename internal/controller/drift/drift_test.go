@@ -0,0 +1,161 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// recorder records the messages of the events it receives.
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.messages = append(r.messages, e.Message)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestDiff(t *testing.T) {
+	type want struct {
+		msg string
+		err string
+	}
+
+	cases := map[string]struct {
+		reason string
+		diff   func(d *Diff)
+		want   want
+	}{
+		"InSync": {
+			reason: "Should report nothing if no field differs",
+			diff: func(d *Diff) {
+				d.Compare("spec.forProvider.name", "a", "a")
+				d.CompareImmutable("spec.forProvider.cidr", "10.0.0.0/24", "10.0.0.0/24")
+				d.Compare("spec.forProvider.secondaryCidrs", []string{"10.1.0.0/16"}, []string{"10.1.0.0/16"})
+				d.Compare("spec.forProvider.routes", []string(nil), []string{})
+				d.Compare("spec.forProvider.tags", map[string]string{"env": "prod"}, map[string]string{"env": "prod"})
+			},
+		},
+		"Collections": {
+			reason: "Should compare slices and maps by their elements",
+			diff: func(d *Diff) {
+				d.Compare("spec.forProvider.secondaryCidrs", []string{"10.1.0.0/16"}, []string{"10.2.0.0/16"})
+				d.Compare("spec.forProvider.tags", map[string]string{"env": "prod"}, map[string]string{"env": "dev"})
+			},
+			want: want{
+				msg: `spec.forProvider.secondaryCidrs: desired [10.1.0.0/16], observed [10.2.0.0/16]; spec.forProvider.tags: desired map[env:prod], observed map[env:dev]`,
+			},
+		},
+		"Mutable": {
+			reason: "Should list differing fields without an error",
+			diff: func(d *Diff) {
+				d.Compare("spec.forProvider.name", "a", "b")
+				d.Compare("spec.forProvider.dhcpEnable", true, false)
+			},
+			want: want{
+				msg: `spec.forProvider.name: desired "a", observed "b"; spec.forProvider.dhcpEnable: desired true, observed false`,
+			},
+		},
+		"Immutable": {
+			reason: "Should explain why immutable fields cannot be updated",
+			diff: func(d *Diff) {
+				d.Compare("spec.forProvider.name", "a", "b")
+				d.CompareImmutable("spec.forProvider.cidr", "10.0.1.0/24", "10.0.0.0/24")
+			},
+			want: want{
+				msg: `spec.forProvider.name: desired "a", observed "b"; spec.forProvider.cidr: desired "10.0.1.0/24", observed "10.0.0.0/24" (immutable)`,
+				err: errImmutable + `: spec.forProvider.cidr: desired "10.0.1.0/24", observed "10.0.0.0/24" (immutable)`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var d Diff
+			tc.diff(&d)
+
+			got := want{msg: Condition(d, 1).Message}
+			if err := d.Err(); err != nil {
+				got.err = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nDiff: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	drifted := func(path, desired, observed string) Diff {
+		var d Diff
+		d.Compare(path, desired, observed)
+		return d
+	}
+
+	cases := map[string]struct {
+		reason string
+		before Diff
+		after  Diff
+		want   []string
+	}{
+		"NewDrift": {
+			reason: "Should record an event when drift is first detected",
+			after:  drifted("spec.forProvider.name", "a", "b"),
+			want:   []string{`spec.forProvider.name: desired "a", observed "b"`},
+		},
+		"SameDrift": {
+			reason: "Should not record an event for drift that was reported before",
+			before: drifted("spec.forProvider.name", "a", "b"),
+			after:  drifted("spec.forProvider.name", "a", "b"),
+		},
+		"ChangedDrift": {
+			reason: "Should record an event when other fields drifted",
+			before: drifted("spec.forProvider.name", "a", "b"),
+			after:  drifted("spec.forProvider.name", "a", "c"),
+			want:   []string{`spec.forProvider.name: desired "a", observed "c"`},
+		},
+		"InSync": {
+			reason: "Should not record an event when the drift was resolved",
+			before: drifted("spec.forProvider.name", "a", "b"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+						mg.SetConditions(Condition(tc.after, 1))
+						return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: tc.after.Empty()}, nil
+					},
+				}, nil
+			}), rec)
+
+			mg := &fake.Managed{}
+			mg.SetConditions(Condition(tc.before, 1))
+
+			ec, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ec.Observe(context.Background(), mg); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, rec.messages); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -2,10 +2,8 @@ package elasticip
 
 import (
 	"context"
-	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -14,6 +12,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/eips"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,6 +22,19 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/elasticip/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/enterprise"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/importer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/tagging"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -35,10 +47,17 @@ const (
 	errCreate       = "cannot create ElasticIP"
 	errDelete       = "cannot delete ElasticIP"
 	errImmutable    = "ElasticIP is immutable"
+	errImport       = "cannot import ElasticIP"
+	errTag          = "cannot tag ElasticIP"
+	errObserveTags  = "cannot observe ElasticIP tags"
+	errUpdateTags   = "cannot update ElasticIP tags"
 )
 
+// tagResourceType is the resource type of EIPs in the tag API.
+const tagResourceType = "publicips"
+
 // SetupGated adds a controller that reconciles ElasticIP managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup ElasticIP controller"))
@@ -48,25 +67,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles ElasticIP managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ElasticIPGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -97,22 +112,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(
-		mgr,
-		resource.ManagedKind(v1alpha1.ElasticIPGroupVersionKind),
-		opts...)
+	kind := resource.ManagedKind(v1alpha1.ElasticIPGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
+	r = dependency.NewReconciler(mgr.GetClient(), kind, r, o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.ElasticIP{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -143,14 +157,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -167,12 +181,76 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: networkClient}, nil
+	// EIP tags are only available in the Network V2 API
+	tagClient, err := providerClient.NewNetworkV2Client()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		client:             networkClient,
+		tagClient:          tagClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+		defaultTags:        spec.DefaultTags,
+		enterpriseProject:  pointer.Deref(spec.EnterpriseProjectID, ""),
+		migrator:           enterprise.NewMigrator(providerClient.NewEPSClient, providerClient.Region),
+	}, nil
 }
 
 // external implements managed.ExternalClient for ElasticIP resources.
 type external struct {
-	client *golangsdk.ServiceClient
+	client    *golangsdk.ServiceClient
+	tagClient *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
+
+	// defaultTags are the default tags of the ProviderConfig.
+	defaultTags map[string]string
+
+	// enterpriseProject is the enterprise project default of the
+	// ProviderConfig.
+	enterpriseProject string
+
+	// migrator migrates EIPs between enterprise projects.
+	migrator *enterprise.Migrator
+}
+
+// publicIP is an EIP including its enterprise project, which the eips package
+// does not expose.
+type publicIP struct {
+	eips.PublicIp
+	EnterpriseProjectID string `json:"enterprise_project_id"`
+}
+
+// applyOpts are the options to apply for an EIP in an enterprise project.
+type applyOpts struct {
+	eips.ApplyOpts
+	EnterpriseProjectID string `json:"enterprise_project_id,omitempty"`
+}
+
+func (opts applyOpts) ToPublicIpApplyMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
+
+// desiredTags returns the desired tags of the supplied EIP, including the
+// default tags of the ProviderConfig.
+func (e *external) desiredTags(spec *v1alpha1.ElasticIPParameters) map[string]string {
+	return tagging.Merge(e.defaultTags, spec.Tags)
+}
+
+// enterpriseProjectID returns the enterprise project of the supplied EIP,
+// defaulting to the enterprise project of the ProviderConfig.
+func (e *external) enterpriseProjectID(spec *v1alpha1.ElasticIPParameters) string {
+	return enterprise.ProjectID(spec.EnterpriseProjectID, e.enterpriseProject)
+}
+
+// get returns the EIP with the supplied ID.
+func (e *external) get(id string) (*publicIP, error) {
+	ip := new(publicIP)
+	err := eips.Get(e.client, id).ExtractIntoStructPtr(ip, "publicip")
+	return ip, err
 }
 
 func (e *external) Observe(
@@ -185,11 +263,22 @@ func (e *external) Observe(
 	}
 
 	externalName := meta.GetExternalName(cr)
+	imported := false
+	if externalName == "" && cr.Spec.ImportSelector != nil {
+		id, err := e.resolveImport(cr.Spec.ImportSelector)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errImport)
+		}
+		// Reporting the managed resource as late initialized persists the
+		// pinned external name.
+		meta.SetExternalName(cr, id)
+		externalName, imported = id, true
+	}
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	eip, err := eips.Get(e.client, externalName).Extract()
+	eip, err := e.get(externalName)
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
@@ -198,16 +287,27 @@ func (e *external) Observe(
 		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
 	}
 
+	var managedTags map[string]string
+	if tagging.Needed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags) {
+		ts, err := e.getTags(eip.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveTags)
+		}
+		managedTags = tagging.Managed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags, ts)
+	}
+
 	// Update observed state
 	cr.Status.AtProvider = v1alpha1.ElasticIPObservation{
-		ID:                 eip.ID,
-		Status:             eip.Status,
-		IPAddress:          eip.PublicAddress,
-		PrivateIPAddress:   eip.PrivateAddress,
-		PortID:             eip.PortID,
-		BandwidthID:        eip.BandwidthID,
-		BandwidthSize:      eip.BandwidthSize,
-		BandwidthShareType: eip.BandwidthShareType,
+		ID:                  eip.ID,
+		Status:              eip.Status,
+		IPAddress:           eip.PublicAddress,
+		PrivateIPAddress:    eip.PrivateAddress,
+		PortID:              eip.PortID,
+		BandwidthID:         eip.BandwidthID,
+		BandwidthSize:       eip.BandwidthSize,
+		BandwidthShareType:  eip.BandwidthShareType,
+		Tags:                managedTags,
+		EnterpriseProjectID: eip.EnterpriseProjectID,
 	}
 
 	// Set conditions based on status
@@ -220,16 +320,47 @@ func (e *external) Observe(
 		cr.SetConditions(xpv1.Creating())
 	}
 
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, eip)
+	diff := e.detectDrift(&cr.Spec.ForProvider, eip, managedTags)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: !needsUpdate,
+		ResourceExists:          true,
+		ResourceUpToDate:        diff.Empty(),
+		ResourceLateInitialized: imported,
 	}, nil
 }
 
-func (e *external) detectDrift(cr *v1alpha1.ElasticIPParameters, eip *eips.PublicIp) bool {
-	return false
+// resolveImport returns the ID of the only EIP that matches the supplied
+// import selector.
+func (e *external) resolveImport(s *v1alpha1.ElasticIPImportSelector) (string, error) {
+	ips, err := eips.List(e.client, eips.ListOpts{
+		PublicAddress: pointer.Deref(s.IPAddress, ""),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ids := make([]string, len(ips))
+	for i, ip := range ips {
+		ids[i] = ip.ID
+	}
+	return importer.Select(ids, s.Tags, e.getTags)
+}
+
+// getTags returns the tags of the EIP with the supplied ID.
+func (e *external) getTags(id string) ([]tags.ResourceTag, error) {
+	return tags.Get(e.tagClient, tagResourceType, id).Extract()
+}
+
+func (e *external) detectDrift(
+	cr *v1alpha1.ElasticIPParameters,
+	eip *publicIP,
+	managedTags map[string]string,
+) drift.Diff {
+	var d drift.Diff
+	enterprise.Compare(&d, "spec.forProvider.enterpriseProjectId", e.enterpriseProjectID(cr), eip.EnterpriseProjectID)
+	tagging.Compare(&d, "spec.forProvider.tags", e.desiredTags(cr), managedTags)
+	return d
 }
 
 func (e *external) Create(
@@ -262,9 +393,12 @@ func (e *external) Create(
 		pubIP.Address = *cr.Spec.ForProvider.PublicIP.IPAddress
 	}
 
-	opts := eips.ApplyOpts{
-		IP:        pubIP,
-		Bandwidth: bw,
+	opts := applyOpts{
+		ApplyOpts: eips.ApplyOpts{
+			IP:        pubIP,
+			Bandwidth: bw,
+		},
+		EnterpriseProjectID: e.enterpriseProjectID(&cr.Spec.ForProvider),
 	}
 
 	eip, err := eips.Apply(e.client, opts).Extract()
@@ -275,6 +409,12 @@ func (e *external) Create(
 	// Set external name to the elasticip ID
 	meta.SetExternalName(cr, eip.ID)
 
+	// EIPs cannot be tagged on creation
+	t := tagging.ResourceTags(e.desiredTags(&cr.Spec.ForProvider))
+	if err := tagging.Apply(e.tagClient, tagResourceType, eip.ID, t, nil); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errTag)
+	}
+
 	return managed.ExternalCreation{}, nil
 }
 
@@ -282,9 +422,34 @@ func (e *external) Update(
 	ctx context.Context,
 	mg resource.Managed,
 ) (managed.ExternalUpdate, error) {
-	// Elastic IPs are immutable. Any detected drift requires recreation, so we
-	// return an error.
-	return managed.ExternalUpdate{}, errors.New(errImmutable)
+	cr, ok := mg.(*v1alpha1.ElasticIP)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotElasticIP)
+	}
+
+	// Elastic IPs are immutable except for their enterprise project and
+	// their tags. Any other detected drift requires recreation, so we return
+	// an error.
+	ep := e.enterpriseProjectID(&cr.Spec.ForProvider)
+	migrate := enterprise.NeedsMigration(ep, cr.Status.AtProvider.EnterpriseProjectID)
+	upsert, remove := tagging.Changes(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags)
+	if !migrate && len(upsert) == 0 && len(remove) == 0 {
+		return managed.ExternalUpdate{}, drift.ErrImmutable(mg, errImmutable)
+	}
+
+	externalName := meta.GetExternalName(cr)
+	if migrate {
+		if err := e.migrator.Migrate(enterprise.ResourceTypeEIP, externalName, ep); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	err := tagging.Apply(e.tagClient, tagResourceType, externalName, upsert, remove)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(
@@ -296,6 +461,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotElasticIP)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -303,7 +472,7 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, nil
 	}
 
-	err := eips.Delete(e.client, externalName).ExtractErr()
+	err := dependency.Wait(cr, eips.Delete(e.client, externalName).ExtractErr(), "ports", "SNAT rules")
 	if err != nil {
 		if errors.Is(err, golangsdk.ErrDefault404{}) {
 			return managed.ExternalDelete{}, nil
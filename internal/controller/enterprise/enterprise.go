@@ -0,0 +1,95 @@
+// Package enterprise assigns external resources to OTC enterprise projects.
+//
+// A managed resource belongs to the enterprise project it sets, or to the
+// enterprise project default of its ProviderConfig. External resources are
+// created in that enterprise project and migrated to it through the
+// Enterprise Project Service once it changes.
+package enterprise
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+// Resource types of the Enterprise Project Service.
+const (
+	ResourceTypeVPC           = "vpcs"
+	ResourceTypeSecurityGroup = "security-groups"
+	ResourceTypeEIP           = "eip"
+	ResourceTypeNATGateway    = "nat_gateways"
+)
+
+const (
+	errNewClient = "cannot create Enterprise Project Service client"
+	errMigrate   = "cannot migrate %s %s to enterprise project %s"
+)
+
+// ProjectID returns the enterprise project a managed resource desires: the
+// supplied one of the managed resource if set, the supplied default of its
+// ProviderConfig otherwise. An empty ID leaves the enterprise project of the
+// external resource alone.
+func ProjectID(id *string, def string) string {
+	return pointer.Deref(id, def)
+}
+
+// Compare adds the enterprise project to the supplied diff if the supplied
+// desired one is set and differs from the observed one.
+func Compare(d *drift.Diff, path, desired, observed string) {
+	if desired == "" {
+		return
+	}
+	d.Compare(path, desired, observed)
+}
+
+// NeedsMigration returns true if an external resource in the supplied
+// observed enterprise project has to be migrated to the desired one.
+func NeedsMigration(desired, observed string) bool {
+	return desired != "" && desired != observed
+}
+
+// A Migrator migrates external resources between enterprise projects.
+type Migrator struct {
+	newClient func() (*golangsdk.ServiceClient, error)
+	region    string
+}
+
+// NewMigrator returns a migrator that migrates the external resources of the
+// supplied region with Enterprise Project Service clients created by the
+// supplied function. Clients are only created to migrate, so a region whose
+// service catalog lacks the Enterprise Project Service only fails to migrate.
+func NewMigrator(newClient func() (*golangsdk.ServiceClient, error), region string) *Migrator {
+	return &Migrator{newClient: newClient, region: region}
+}
+
+// migrateOpts is the request body of a migration.
+type migrateOpts struct {
+	ProjectID    string `json:"project_id"`
+	RegionID     string `json:"region_id"`
+	ResourceID   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+	Associated   bool   `json:"associated"`
+}
+
+// Migrate migrates the external resource of the supplied type and ID to the
+// supplied enterprise project. Associated resources, e.g. the bandwidth of an
+// EIP, are not migrated along.
+func (m *Migrator) Migrate(resourceType, id, project string) error {
+	sc, err := m.newClient()
+	if err != nil {
+		return errors.Wrap(err, errNewClient)
+	}
+
+	opts := migrateOpts{
+		ProjectID:    sc.ProjectID,
+		RegionID:     m.region,
+		ResourceID:   id,
+		ResourceType: resourceType,
+	}
+	_, err = sc.Post(sc.ServiceURL("enterprise-projects", project, "resources-migrate"), opts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return errors.Wrapf(err, errMigrate, resourceType, id, project)
+}
@@ -0,0 +1,138 @@
+package enterprise
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+	fake "github.com/opentelekomcloud/gophertelekomcloud/testhelper/client"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+)
+
+func TestProjectID(t *testing.T) {
+	type args struct {
+		id       *string
+		def      string
+		observed string
+	}
+
+	type want struct {
+		id      string
+		drift   string
+		migrate bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unset": {
+			reason: "Should leave the enterprise project alone if neither the managed resource nor the ProviderConfig sets one",
+			args:   args{observed: "0"},
+		},
+		"Default": {
+			reason: "Should apply the enterprise project default of the ProviderConfig",
+			args:   args{def: "ep-finance", observed: "0"},
+			want: want{
+				id:      "ep-finance",
+				drift:   `spec.forProvider.enterpriseProjectId: desired "ep-finance", observed "0"`,
+				migrate: true,
+			},
+		},
+		"Override": {
+			reason: "Should prefer the enterprise project of the managed resource over the default",
+			args:   args{id: pointer.To("ep-network"), def: "ep-finance", observed: "ep-network"},
+			want: want{
+				id: "ep-network",
+			},
+		},
+		"DefaultProject": {
+			reason: "Should migrate back to the default enterprise project 0",
+			args:   args{id: pointer.To("0"), def: "ep-finance", observed: "ep-finance"},
+			want: want{
+				id:      "0",
+				drift:   `spec.forProvider.enterpriseProjectId: desired "0", observed "ep-finance"`,
+				migrate: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id := ProjectID(tc.args.id, tc.args.def)
+
+			var d drift.Diff
+			Compare(&d, "spec.forProvider.enterpriseProjectId", id, tc.args.observed)
+
+			got := want{id: id, drift: d.String(), migrate: NeedsMigration(id, tc.args.observed)}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nProjectID, Compare, NeedsMigration: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		code   int
+		want   bool
+	}{
+		"Migrated": {
+			reason: "Should migrate the resource through the Enterprise Project Service",
+			code:   http.StatusNoContent,
+		},
+		"Refused": {
+			reason: "Should return an error if the migration is refused",
+			code:   http.StatusForbidden,
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			testhelper.Mux.HandleFunc("/v1.0/enterprise-projects/ep-finance/resources-migrate", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "POST")
+				testhelper.TestJSONRequest(t, r, `{
+					"project_id": "project",
+					"region_id": "eu-de",
+					"resource_id": "eip-id-123",
+					"resource_type": "eip",
+					"associated": false
+				}`)
+				w.WriteHeader(tc.code)
+			})
+
+			m := NewMigrator(func() (*golangsdk.ServiceClient, error) {
+				sc := fake.ServiceClient()
+				sc.ProjectID = "project"
+				sc.ResourceBase = testhelper.Endpoint() + "v1.0/"
+				return sc, nil
+			}, "eu-de")
+
+			err := m.Migrate(ResourceTypeEIP, "eip-id-123", "ep-finance")
+			if diff := cmp.Diff(tc.want, err != nil); diff != "" {
+				t.Errorf("\n%s\nm.Migrate(...): -want error, +got error:\n%s\n%v\n", tc.reason, diff, err)
+			}
+		})
+	}
+}
+
+func TestMigrateNoEndpoint(t *testing.T) {
+	m := NewMigrator(func() (*golangsdk.ServiceClient, error) {
+		return nil, errors.New("no suitable endpoint could be found")
+	}, "eu-de")
+
+	if err := m.Migrate(ResourceTypeVPC, "vpc-id-123", "ep-finance"); err == nil {
+		t.Error("m.Migrate(...): want error if the Enterprise Project Service has no endpoint, got nil")
+	}
+}
@@ -0,0 +1,65 @@
+// Package importer resolves the import selectors of managed resources to the
+// ID of the existing external resource to import.
+package importer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+)
+
+const (
+	errNoMatch = "no external resource matches the import selector"
+	errSeveral = "more than one external resource matches the import selector"
+	errGetTags = "cannot get tags of external resource"
+)
+
+// TagsFn returns the tags of the external resource with the supplied ID.
+type TagsFn func(id string) ([]tags.ResourceTag, error)
+
+// Select returns the ID of the only candidate that carries all supplied tags.
+// The candidates are the IDs of the external resources that match the other
+// fields of an import selector, as returned by a list API. The tags of a
+// candidate are only fetched if tags are supplied. Select returns an error if
+// no or more than one candidate matches.
+func Select(candidates []string, want map[string]string, tagsOf TagsFn) (string, error) {
+	var ids []string
+	for _, id := range candidates {
+		if len(want) > 0 {
+			ts, err := tagsOf(id)
+			if err != nil {
+				return "", errors.Wrapf(err, "%s %s", errGetTags, id)
+			}
+			if !Carries(ts, want) {
+				continue
+			}
+		}
+		ids = append(ids, id)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", errors.New(errNoMatch)
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", errors.Errorf("%s: %s", errSeveral, strings.Join(ids, ", "))
+	}
+}
+
+// Carries returns true if the supplied tags contain all wanted tags.
+func Carries(ts []tags.ResourceTag, want map[string]string) bool {
+	have := make(map[string]string, len(ts))
+	for _, t := range ts {
+		have[t.Key] = t.Value
+	}
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
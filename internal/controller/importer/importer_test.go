@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+)
+
+func TestSelect(t *testing.T) {
+	resourceTags := map[string][]tags.ResourceTag{
+		"a": {{Key: "env", Value: "prod"}, {Key: "team", Value: "net"}},
+		"b": {{Key: "env", Value: "dev"}},
+		"c": {{Key: "env", Value: "prod"}},
+	}
+	tagsOf := func(id string) ([]tags.ResourceTag, error) {
+		if id == "broken" {
+			return nil, errors.New("boom")
+		}
+		return resourceTags[id], nil
+	}
+
+	type args struct {
+		candidates []string
+		want       map[string]string
+	}
+
+	type want struct {
+		id  string
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Single": {
+			reason: "Should import the only candidate if no tags are selected",
+			args:   args{candidates: []string{"b"}},
+			want:   want{id: "b"},
+		},
+		"Tags": {
+			reason: "Should import the only candidate that carries all selected tags",
+			args: args{
+				candidates: []string{"a", "b", "c"},
+				want:       map[string]string{"env": "prod", "team": "net"},
+			},
+			want: want{id: "a"},
+		},
+		"NoMatch": {
+			reason: "Should fail if no candidate matches",
+			args: args{
+				candidates: []string{"a", "b"},
+				want:       map[string]string{"env": "staging"},
+			},
+			want: want{err: true},
+		},
+		"NoCandidates": {
+			reason: "Should fail if there are no candidates",
+			want:   want{err: true},
+		},
+		"Several": {
+			reason: "Should fail if more than one candidate matches",
+			args: args{
+				candidates: []string{"a", "b", "c"},
+				want:       map[string]string{"env": "prod"},
+			},
+			want: want{err: true},
+		},
+		"TagsError": {
+			reason: "Should fail if the tags of a candidate cannot be fetched",
+			args: args{
+				candidates: []string{"a", "broken"},
+				want:       map[string]string{"env": "prod"},
+			},
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, err := Select(tc.args.candidates, tc.args.want, tagsOf)
+			got := want{id: id, err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nSelect(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -2,11 +2,9 @@ package natgateway
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -15,6 +13,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/extensions/natgateways"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -24,7 +23,19 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/natgateway/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/enterprise"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/importer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/tagging"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -37,10 +48,17 @@ const (
 	errCreate        = "cannot create NATGateway"
 	errUpdate        = "cannot update NATGateway"
 	errDelete        = "cannot delete NATGateway"
+	errImport        = "cannot import NATGateway"
+	errTag           = "cannot tag NATGateway"
+	errObserveTags   = "cannot observe NATGateway tags"
+	errUpdateTags    = "cannot update NATGateway tags"
 )
 
+// tagResourceType is the resource type of NAT gateways in the tag API.
+const tagResourceType = "nat_gateways"
+
 // SetupGated adds a controller that reconciles NATGateway managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup NATGateway controller"))
@@ -50,25 +68,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles NATGateway managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.NATGatewayGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -99,22 +113,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(
-		mgr,
-		resource.ManagedKind(v1alpha1.NATGatewayGroupVersionKind),
-		opts...)
+	kind := resource.ManagedKind(v1alpha1.NATGatewayGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
+	r = dependency.NewReconciler(mgr.GetClient(), kind, r, o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.NATGateway{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -146,14 +159,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -170,12 +183,86 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: networkClient}, nil
+	// NAT gateway tags are only available in the NAT API
+	tagClient, err := providerClient.NewNatV2Client()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		client:             networkClient,
+		tagClient:          tagClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+		defaultTags:        spec.DefaultTags,
+		namePrefix:         pointer.Deref(spec.NamePrefix, ""),
+		enterpriseProject:  pointer.Deref(spec.EnterpriseProjectID, ""),
+		migrator:           enterprise.NewMigrator(providerClient.NewEPSClient, providerClient.Region),
+	}, nil
 }
 
 // external implements managed.ExternalClient for NATGateway resources.
 type external struct {
-	client *golangsdk.ServiceClient
+	client    *golangsdk.ServiceClient
+	tagClient *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
+
+	// defaultTags are the default tags of the ProviderConfig.
+	defaultTags map[string]string
+
+	// namePrefix is the name prefix of the ProviderConfig.
+	namePrefix string
+
+	// enterpriseProject is the enterprise project default of the
+	// ProviderConfig.
+	enterpriseProject string
+
+	// migrator migrates NAT Gateways between enterprise projects.
+	migrator *enterprise.Migrator
+}
+
+// observedGateway is a NAT Gateway including its enterprise project, which the
+// natgateways package does not expose.
+type observedGateway struct {
+	natgateways.NatGateway
+	EnterpriseProjectID string `json:"enterprise_project_id"`
+}
+
+// createOpts are the options to create a NAT Gateway in an enterprise project.
+type createOpts struct {
+	natgateways.CreateOpts
+	EnterpriseProjectID string `json:"enterprise_project_id,omitempty"`
+}
+
+func (opts createOpts) ToNatGatewayCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "nat_gateway")
+}
+
+// desiredTags returns the desired tags of the supplied NAT Gateway, including the
+// default tags of the ProviderConfig.
+func (e *external) desiredTags(spec *v1alpha1.NATGatewayParameters) map[string]string {
+	return tagging.Merge(e.defaultTags, spec.Tags)
+}
+
+// name returns the name of the supplied NAT Gateway, including the name prefix
+// of the ProviderConfig.
+func (e *external) name(spec *v1alpha1.NATGatewayParameters) string {
+	return e.namePrefix + spec.Name
+}
+
+// enterpriseProjectID returns the enterprise project of the supplied NAT
+// Gateway, defaulting to the enterprise project of the ProviderConfig.
+func (e *external) enterpriseProjectID(spec *v1alpha1.NATGatewayParameters) string {
+	return enterprise.ProjectID(spec.EnterpriseProjectID, e.enterpriseProject)
+}
+
+// get returns the NAT Gateway with the supplied ID.
+func (e *external) get(id string) (*observedGateway, error) {
+	gateway := new(observedGateway)
+	err := natgateways.Get(e.client, id).ExtractIntoStructPtr(gateway, "nat_gateway")
+	return gateway, err
 }
 
 func (e *external) Observe(
@@ -188,11 +275,22 @@ func (e *external) Observe(
 	}
 
 	externalName := meta.GetExternalName(cr)
+	imported := false
+	if externalName == "" && cr.Spec.ImportSelector != nil {
+		id, err := e.resolveImport(cr.Spec.ImportSelector)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errImport)
+		}
+		// Reporting the managed resource as late initialized persists the
+		// pinned external name.
+		meta.SetExternalName(cr, id)
+		externalName, imported = id, true
+	}
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	gateway, err := natgateways.Get(e.client, externalName).Extract()
+	gateway, err := e.get(externalName)
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
@@ -201,13 +299,24 @@ func (e *external) Observe(
 		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
 	}
 
+	var managedTags map[string]string
+	if tagging.Needed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags) {
+		ts, err := e.getTags(gateway.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveTags)
+		}
+		managedTags = tagging.Managed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags, ts)
+	}
+
 	// Update observed state
 	cr.Status.AtProvider = v1alpha1.NATGatewayObservation{
-		ID:           gateway.ID,
-		Status:       gateway.Status,
-		AdminStateUp: gateway.AdminStateUp,
-		VPCID:        gateway.RouterID,
-		SubnetID:     gateway.InternalNetworkID,
+		ID:                  gateway.ID,
+		Status:              gateway.Status,
+		AdminStateUp:        gateway.AdminStateUp,
+		VPCID:               gateway.RouterID,
+		SubnetID:            gateway.InternalNetworkID,
+		EnterpriseProjectID: gateway.EnterpriseProjectID,
+		Tags:                managedTags,
 	}
 
 	// Set conditions based on status
@@ -222,16 +331,43 @@ func (e *external) Observe(
 		cr.SetConditions(xpv1.Unavailable())
 	}
 
-	lateInitialized := e.detectLateInitialization(&cr.Spec.ForProvider, &gateway)
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, &gateway)
+	lateInitialized := e.detectLateInitialization(&cr.Spec.ForProvider, &gateway.NatGateway)
+	diff := e.detectDrift(&cr.Spec.ForProvider, gateway, managedTags)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        !needsUpdate,
-		ResourceLateInitialized: lateInitialized,
+		ResourceUpToDate:        diff.Empty(),
+		ResourceLateInitialized: lateInitialized || imported,
 	}, nil
 }
 
+// resolveImport returns the ID of the only NAT gateway that matches the
+// supplied import selector.
+func (e *external) resolveImport(s *v1alpha1.NATGatewayImportSelector) (string, error) {
+	pages, err := natgateways.List(e.client, natgateways.ListOpts{
+		Name: pointer.Deref(s.Name, ""),
+	}).AllPages()
+	if err != nil {
+		return "", err
+	}
+	gateways, err := natgateways.ExtractNatGateways(pages)
+	if err != nil {
+		return "", err
+	}
+
+	ids := make([]string, len(gateways))
+	for i, g := range gateways {
+		ids[i] = g.ID
+	}
+	return importer.Select(ids, s.Tags, e.getTags)
+}
+
+// getTags returns the tags of the NAT gateway with the supplied ID.
+func (e *external) getTags(id string) ([]tags.ResourceTag, error) {
+	return tags.Get(e.tagClient, tagResourceType, id).Extract()
+}
+
 // detectLateInitialization fills optional Spec fields if they are empty but present at the provider.
 func (e *external) detectLateInitialization(
 	spec *v1alpha1.NATGatewayParameters,
@@ -249,28 +385,18 @@ func (e *external) detectLateInitialization(
 
 func (e *external) detectDrift(
 	spec *v1alpha1.NATGatewayParameters,
-	actual *natgateways.NatGateway,
-) bool {
-	if actual.Name != spec.Name {
-		return true
-	}
-	if pointer.Deref(spec.Description, actual.Description) != actual.Description {
-		return true
-	}
-
-	desiredSpec := resolveSpecID(spec.Spec)
-	if actual.Spec != desiredSpec {
-		return true
-	}
-
-	if actual.RouterID != spec.VPCID {
-		return true
-	}
-	if actual.InternalNetworkID != spec.SubnetID {
-		return true
-	}
-
-	return false
+	actual *observedGateway,
+	managedTags map[string]string,
+) drift.Diff {
+	var d drift.Diff
+	d.Compare("spec.forProvider.name", e.name(spec), actual.Name)
+	d.Compare("spec.forProvider.description", pointer.Deref(spec.Description, actual.Description), actual.Description)
+	d.Compare("spec.forProvider.spec", resolveSpecID(spec.Spec), actual.Spec)
+	d.CompareImmutable("spec.forProvider.vpcId", spec.VPCID, actual.RouterID)
+	d.CompareImmutable("spec.forProvider.subnetId", spec.SubnetID, actual.InternalNetworkID)
+	enterprise.Compare(&d, "spec.forProvider.enterpriseProjectId", e.enterpriseProjectID(spec), actual.EnterpriseProjectID)
+	tagging.Compare(&d, "spec.forProvider.tags", e.desiredTags(spec), managedTags)
+	return d
 }
 
 func (e *external) Create(
@@ -287,11 +413,14 @@ func (e *external) Create(
 	// Translate human-readable spec
 	spec := resolveSpecID(cr.Spec.ForProvider.Spec)
 
-	opts := natgateways.CreateOpts{
-		Name:              cr.Spec.ForProvider.Name,
-		Spec:              spec,
-		RouterID:          cr.Spec.ForProvider.VPCID,
-		InternalNetworkID: cr.Spec.ForProvider.SubnetID,
+	opts := createOpts{
+		CreateOpts: natgateways.CreateOpts{
+			Name:              e.name(&cr.Spec.ForProvider),
+			Spec:              spec,
+			RouterID:          cr.Spec.ForProvider.VPCID,
+			InternalNetworkID: cr.Spec.ForProvider.SubnetID,
+		},
+		EnterpriseProjectID: e.enterpriseProjectID(&cr.Spec.ForProvider),
 	}
 
 	if cr.Spec.ForProvider.Description != nil {
@@ -306,6 +435,12 @@ func (e *external) Create(
 	// Set external name to the NAT Gateway ID
 	meta.SetExternalName(cr, gateway.ID)
 
+	// NAT Gateways cannot be tagged on creation
+	t := tagging.ResourceTags(e.desiredTags(&cr.Spec.ForProvider))
+	if err := tagging.Apply(e.tagClient, tagResourceType, gateway.ID, t, nil); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errTag)
+	}
+
 	return managed.ExternalCreation{}, nil
 }
 
@@ -319,11 +454,11 @@ func (e *external) Update(
 	}
 
 	// Verify immutable fields
-	if cr.Spec.ForProvider.VPCID != cr.Status.AtProvider.VPCID {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: VPCID")
-	}
-	if cr.Spec.ForProvider.SubnetID != cr.Status.AtProvider.SubnetID {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: SubnetID")
+	var immutable drift.Diff
+	immutable.CompareImmutable("spec.forProvider.vpcId", cr.Spec.ForProvider.VPCID, cr.Status.AtProvider.VPCID)
+	immutable.CompareImmutable("spec.forProvider.subnetId", cr.Spec.ForProvider.SubnetID, cr.Status.AtProvider.SubnetID)
+	if err := immutable.Err(); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	externalName := meta.GetExternalName(cr)
@@ -332,7 +467,7 @@ func (e *external) Update(
 	spec := resolveSpecID(cr.Spec.ForProvider.Spec)
 
 	opts := natgateways.UpdateOpts{
-		Name: cr.Spec.ForProvider.Name,
+		Name: e.name(&cr.Spec.ForProvider),
 		Spec: spec,
 	}
 
@@ -345,6 +480,18 @@ func (e *external) Update(
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 	}
 
+	ep := e.enterpriseProjectID(&cr.Spec.ForProvider)
+	if enterprise.NeedsMigration(ep, cr.Status.AtProvider.EnterpriseProjectID) {
+		if err := e.migrator.Migrate(enterprise.ResourceTypeNATGateway, externalName, ep); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	upsert, remove := tagging.Changes(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags)
+	if err := tagging.Apply(e.tagClient, tagResourceType, externalName, upsert, remove); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -357,6 +504,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotNATGateway)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -364,7 +515,7 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, nil
 	}
 
-	err := natgateways.Delete(e.client, externalName).ExtractErr()
+	err := dependency.Wait(cr, natgateways.Delete(e.client, externalName).ExtractErr(), "SNAT rules")
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
@@ -1,9 +1,190 @@
 package natgateway
 
 import (
+	"context"
+	"net/http"
 	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentelekomcloud/gophertelekomcloud/testhelper"
+	fake "github.com/opentelekomcloud/gophertelekomcloud/testhelper/client"
+	corev1 "k8s.io/api/core/v1"
+
+	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/natgateway/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
 )
 
+type params func(*v1alpha1.NATGateway)
+
+func natGateway(p ...params) *v1alpha1.NATGateway {
+	n := &v1alpha1.NATGateway{}
+	for _, f := range p {
+		f(n)
+	}
+	return n
+}
+
+func withExternalName(name string) params {
+	return func(n *v1alpha1.NATGateway) {
+		meta.SetExternalName(n, name)
+	}
+}
+
+func withAnnotation(key, value string) params {
+	return func(n *v1alpha1.NATGateway) {
+		meta.AddAnnotations(n, map[string]string{key: value})
+	}
+}
+
+func withConditions(c ...xpv1.Condition) params {
+	return func(n *v1alpha1.NATGateway) {
+		n.SetConditions(c...)
+	}
+}
+
 func TestObserve(t *testing.T) {
 
 }
+
+func TestDelete(t *testing.T) {
+	type fields struct {
+		deletionProtection bool
+	}
+
+	type args struct {
+		mg   *v1alpha1.NATGateway
+		code int
+		body string
+	}
+
+	type want struct {
+		deleted   bool
+		err       bool
+		protected corev1.ConditionStatus
+		waiting   corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"Unprotected": {
+			reason: "Should delete unprotected NAT Gateways",
+			args: args{
+				mg:   natGateway(withExternalName("nat-id-123")),
+				code: http.StatusNoContent,
+			},
+			want: want{
+				deleted:   true,
+				protected: corev1.ConditionFalse,
+				waiting:   corev1.ConditionUnknown,
+			},
+		},
+		"Annotation": {
+			reason: "Should refuse to delete NAT Gateways protected by the annotation",
+			args: args{
+				mg: natGateway(
+					withExternalName("nat-id-123"),
+					withAnnotation(protection.AnnotationDeletionProtection, "true"),
+				),
+			},
+			want: want{
+				err:       true,
+				protected: corev1.ConditionTrue,
+				waiting:   corev1.ConditionUnknown,
+			},
+		},
+		"ProviderConfigDefault": {
+			reason: "Should refuse to delete NAT Gateways protected by the ProviderConfig default",
+			fields: fields{deletionProtection: true},
+			args: args{
+				mg: natGateway(withExternalName("nat-id-123")),
+			},
+			want: want{
+				err:       true,
+				protected: corev1.ConditionTrue,
+				waiting:   corev1.ConditionUnknown,
+			},
+		},
+		"Lifted": {
+			reason: "Should clear the refusal once the annotation lifts the protection",
+			fields: fields{deletionProtection: true},
+			args: args{
+				mg: natGateway(
+					withExternalName("nat-id-123"),
+					withAnnotation(protection.AnnotationDeletionProtection, "false"),
+					withConditions(protection.Refused()),
+				),
+				code: http.StatusNoContent,
+			},
+			want: want{
+				deleted:   true,
+				protected: corev1.ConditionFalse,
+				waiting:   corev1.ConditionUnknown,
+			},
+		},
+		"SNATRulesExist": {
+			reason: "Should wait for the SNAT rules of the NAT Gateway to be deleted",
+			args: args{
+				mg:   natGateway(withExternalName("nat-id-123")),
+				code: http.StatusBadRequest,
+				body: `{"error_code":"NAT.0012","error_msg":"Nat gateway has snat or dnat rules."}`,
+			},
+			want: want{
+				deleted:   true,
+				err:       true,
+				protected: corev1.ConditionFalse,
+				waiting:   corev1.ConditionTrue,
+			},
+		},
+		"NotFound": {
+			reason: "Should treat NAT Gateways that no longer exist as deleted",
+			args: args{
+				mg:   natGateway(withExternalName("nat-id-123")),
+				code: http.StatusNotFound,
+			},
+			want: want{
+				deleted:   true,
+				protected: corev1.ConditionFalse,
+				waiting:   corev1.ConditionUnknown,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			deleted := false
+			testhelper.Mux.HandleFunc("/nat_gateways/nat-id-123", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "DELETE")
+				deleted = true
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(tc.args.code)
+				_, _ = w.Write([]byte(tc.args.body))
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+
+			e := external{client: sc, deletionProtection: tc.fields.deletionProtection}
+			_, err := e.Delete(context.Background(), tc.args.mg)
+
+			got := want{
+				deleted:   deleted,
+				err:       err != nil,
+				protected: tc.args.mg.GetCondition(protection.TypeDeletionProtected).Status,
+				waiting:   tc.args.mg.GetCondition(dependency.TypeWaitingForDependents).Status,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
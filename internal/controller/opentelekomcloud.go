@@ -1,12 +1,12 @@
 package controller
 
 import (
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/config"
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/elasticip"
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/natgateway"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/securitygroup"
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/securitygrouprule"
 	"github.com/peertechde/provider-opentelekomcloud/internal/controller/subnet"
@@ -15,8 +15,8 @@ import (
 
 // SetupGated creates all OpenTelekomCloud controllers with safe-start support and adds them to
 // the supplied manager.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
+	for _, setup := range []func(ctrl.Manager, options.Options) error{
 		config.Setup,
 		vpc.SetupGated,
 		subnet.SetupGated,
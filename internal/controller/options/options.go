@@ -0,0 +1,29 @@
+// Package options contains the options shared by all OpenTelekomCloud
+// controllers.
+package options
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+// Options configures the OpenTelekomCloud controllers. In addition to the
+// common crossplane controller options it carries state that is shared
+// provider-wide.
+type Options struct {
+	controller.Options
+
+	// Sessions is the OTC session cache shared by all controllers.
+	Sessions *clients.Cache
+
+	// ProviderConfigCheckInterval is how often the credentials of every
+	// ProviderConfig are validated.
+	ProviderConfigCheckInterval time.Duration
+
+	// Plan makes the controllers only record the changes they would make to
+	// external resources, unless a managed resource opts out.
+	Plan bool
+}
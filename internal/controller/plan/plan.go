@@ -0,0 +1,267 @@
+// Package plan computes the changes the provider would make to external
+// resources without making them.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+)
+
+// AnnotationPlan switches a managed resource into or out of plan mode. Its
+// value is parsed as a bool and takes precedence over the --plan flag of the
+// provider.
+const AnnotationPlan = "opentelekomcloud.crossplane.io/plan"
+
+// TypePlanned managed resources are in plan mode. The reason of the condition
+// is the action the provider would take, and the message the changes it would
+// make.
+const TypePlanned xpv1.ConditionType = "Planned"
+
+// Actions the provider would take in plan mode.
+const (
+	ActionNone   xpv1.ConditionReason = "None"
+	ActionCreate xpv1.ConditionReason = "Create"
+	ActionUpdate xpv1.ConditionReason = "Update"
+	ActionDelete xpv1.ConditionReason = "Delete"
+)
+
+// ReasonPlanDisabled indicates that a managed resource left plan mode.
+const ReasonPlanDisabled xpv1.ConditionReason = "PlanDisabled"
+
+const (
+	errPlanned = "not deleting the external resource in plan mode"
+	errCopy    = "cannot copy managed resource"
+)
+
+// Enabled returns true if the supplied managed resource is in plan mode,
+// given whether plan mode is enabled for the whole provider.
+func Enabled(mg metav1.Object, global bool) bool {
+	v, ok := mg.GetAnnotations()[AnnotationPlan]
+	if !ok {
+		return global
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return global
+	}
+	return enabled
+}
+
+// Planned returns a condition that indicates that the provider would take the
+// supplied action with the supplied changes.
+func Planned(action xpv1.ConditionReason, changes string, generation int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             action,
+		Message:            changes,
+		ObservedGeneration: generation,
+	}
+}
+
+// Disabled returns a condition that indicates that a managed resource is no
+// longer in plan mode.
+func Disabled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPlanDisabled,
+	}
+}
+
+// NewConnector returns a connector whose clients do not create, update or
+// delete external resources of managed resources in plan mode. Instead they
+// record the intended action and its changes in the Planned condition and an
+// event. Plan mode is enabled for all managed resources if global is true,
+// and per managed resource by the AnnotationPlan annotation.
+func NewConnector(c managed.ExternalConnector, global bool, r event.Recorder) managed.ExternalConnector {
+	return &connector{next: c, global: global, record: r}
+}
+
+type connector struct {
+	next   managed.ExternalConnector
+	global bool
+	record event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.next.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connector: c, next: ec}, nil
+}
+
+type external struct {
+	*connector
+	next managed.ExternalClient
+}
+
+// Observe reports planned creations and updates as done, so that the managed
+// reconciler does not call Create or Update. Otherwise it would call them
+// again and again while waiting for the planned changes to show up. Imports
+// and late initialization are reported as planned changes, too, instead of
+// being written to the managed resource.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if !Enabled(mg, e.global) {
+		o, err := e.next.Observe(ctx, mg)
+		if err == nil && mg.GetCondition(TypePlanned).Status == corev1.ConditionTrue {
+			mg.SetConditions(Disabled())
+		}
+		return o, err
+	}
+
+	before, ok := mg.DeepCopyObject().(resource.Managed)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errCopy)
+	}
+	o, err := e.next.Observe(ctx, mg)
+	writes := revert(mg, before)
+	o.ResourceLateInitialized = false
+	if err != nil {
+		return o, err
+	}
+
+	switch {
+	case meta.WasDeleted(mg):
+		// Delete records the planned deletion.
+		return o, nil
+	case !o.ResourceExists:
+		e.plan(mg, ActionCreate, desired(mg))
+		o.ResourceExists, o.ResourceUpToDate = true, true
+	case !o.ResourceUpToDate:
+		changes := "the external resource is not up to date"
+		if c := mg.GetCondition(drift.TypeDrifted); c.Status == corev1.ConditionTrue {
+			changes = c.Message
+		}
+		e.plan(mg, ActionUpdate, join(changes, writes))
+		o.ResourceUpToDate = true
+	default:
+		e.plan(mg, ActionNone, writes)
+	}
+	return o, nil
+}
+
+// revert reverts the changes that observing the supplied managed resource
+// made to its spec and its external name, e.g. by importing or late
+// initializing it, to the supplied copy taken before. It returns a
+// description of the reverted changes.
+func revert(mg, before resource.Managed) string {
+	var writes []string
+	if name := meta.GetExternalName(mg); name != meta.GetExternalName(before) {
+		writes = append(writes, "would import the external resource "+name)
+	}
+	mg.SetAnnotations(before.GetAnnotations())
+
+	now, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return strings.Join(writes, "; ")
+	}
+	was, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return strings.Join(writes, "; ")
+	}
+	if !equality.Semantic.DeepEqual(now["spec"], was["spec"]) {
+		writes = append(writes, "would late initialize spec.forProvider")
+		// Keep the observed status.
+		was["status"] = now["status"]
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(was, mg)
+	}
+	return strings.Join(writes, "; ")
+}
+
+// join joins the supplied descriptions of changes.
+func join(changes ...string) string {
+	var cs []string
+	for _, c := range changes {
+		if c != "" {
+			cs = append(cs, c)
+		}
+	}
+	return strings.Join(cs, "; ")
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if Enabled(mg, e.global) {
+		e.plan(mg, ActionCreate, desired(mg))
+		return managed.ExternalCreation{}, nil
+	}
+	return e.next.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if Enabled(mg, e.global) {
+		e.plan(mg, ActionUpdate, mg.GetCondition(drift.TypeDrifted).Message)
+		return managed.ExternalUpdate{}, nil
+	}
+	return e.next.Update(ctx, mg)
+}
+
+// Delete returns an error for managed resources in plan mode, which keeps
+// their finalizer until they leave plan mode.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	if Enabled(mg, e.global) {
+		e.plan(mg, ActionDelete, meta.GetExternalName(mg))
+		return managed.ExternalDelete{}, errors.New(errPlanned)
+	}
+	return e.next.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
+
+// plan sets the Planned condition of the supplied managed resource and
+// records an event if the planned action or its changes differ from the last
+// ones.
+func (e *external) plan(mg resource.Managed, action xpv1.ConditionReason, changes string) {
+	before := mg.GetCondition(TypePlanned)
+	c := Planned(action, changes, mg.GetGeneration())
+	mg.SetConditions(c)
+
+	if action == ActionNone || before.Equal(c) {
+		return
+	}
+	msg := fmt.Sprintf("Plan mode: would %s the external resource", strings.ToLower(string(action)))
+	if changes != "" {
+		msg += ": " + changes
+	}
+	e.record.Event(mg, event.Normal(event.Reason("Planned"+string(action)), msg))
+}
+
+// desired returns the desired state of the supplied managed resource, i.e.
+// its spec.forProvider, as JSON.
+func desired(mg resource.Managed) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return ""
+	}
+	v, err := fieldpath.Pave(u).GetValue("spec.forProvider")
+	if err != nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
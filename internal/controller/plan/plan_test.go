@@ -0,0 +1,287 @@
+package plan
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	vpcv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/vpc/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+)
+
+// recorder records the reasons of the events it receives.
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func managedWith(annotations map[string]string, c ...xpv1.Condition) *fake.Managed {
+	mg := &fake.Managed{}
+	mg.SetAnnotations(annotations)
+	mg.SetConditions(c...)
+	return mg
+}
+
+func TestObserve(t *testing.T) {
+	var d drift.Diff
+	d.Compare("spec.forProvider.primaryDns", "100.125.4.25", "100.125.129.199")
+
+	type args struct {
+		global bool
+		mg     *fake.Managed
+		o      managed.ExternalObservation
+	}
+
+	type want struct {
+		o       managed.ExternalObservation
+		action  xpv1.ConditionReason
+		changes string
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Disabled": {
+			reason: "Should pass observations through outside of plan mode",
+			args: args{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"OptedOut": {
+			reason: "Should let the annotation take precedence over the flag",
+			args: args{
+				global: true,
+				mg:     managedWith(map[string]string{AnnotationPlan: "false"}),
+				o:      managed.ExternalObservation{ResourceExists: false},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"PlannedCreate": {
+			reason: "Should plan the creation of missing external resources",
+			args: args{
+				global: true,
+				o:      managed.ExternalObservation{ResourceExists: false},
+			},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action:  ActionCreate,
+				reasons: []event.Reason{"PlannedCreate"},
+			},
+		},
+		"PlannedUpdate": {
+			reason: "Should plan the update of drifted external resources with their diff",
+			args: args{
+				mg: managedWith(map[string]string{AnnotationPlan: "true"}, drift.Condition(d, 0)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action:  ActionUpdate,
+				changes: d.String(),
+				reasons: []event.Reason{"PlannedUpdate"},
+			},
+		},
+		"SamePlan": {
+			reason: "Should not record an event for a plan that was recorded before",
+			args: args{
+				mg: managedWith(map[string]string{AnnotationPlan: "true"}, drift.Condition(d, 0), Planned(ActionUpdate, d.String(), 0)),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action:  ActionUpdate,
+				changes: d.String(),
+			},
+		},
+		"NothingToDo": {
+			reason: "Should plan no action for external resources that are up to date",
+			args: args{
+				global: true,
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action: ActionNone,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := tc.args.mg
+			if mg == nil {
+				mg = managedWith(nil)
+			}
+			rec := &recorder{}
+			c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return tc.args.o, nil
+					},
+				}, nil
+			}), tc.args.global, rec)
+
+			ec, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := ec.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cond := mg.GetCondition(TypePlanned)
+			got := want{o: o, action: cond.Reason, changes: cond.Message, reasons: rec.reasons}
+			if tc.want.action == ActionCreate {
+				got.changes = ""
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveWrites(t *testing.T) {
+	type want struct {
+		o           managed.ExternalObservation
+		action      xpv1.ConditionReason
+		changes     string
+		externalID  string
+		description *string
+		observedID  string
+	}
+
+	cases := map[string]struct {
+		reason     string
+		externalID string
+		observe    func(mg *vpcv1alpha1.VPC)
+		o          managed.ExternalObservation
+		want       want
+	}{
+		"Import": {
+			reason: "Should plan the import of an external resource instead of setting the external name",
+			observe: func(mg *vpcv1alpha1.VPC) {
+				meta.SetExternalName(mg, "vpc-a")
+				mg.Status.AtProvider.ID = "vpc-a"
+			},
+			o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action:     ActionNone,
+				changes:    "would import the external resource vpc-a",
+				observedID: "vpc-a",
+			},
+		},
+		"LateInitialize": {
+			reason:     "Should plan the late initialization of the spec instead of writing it",
+			externalID: "vpc-a",
+			observe: func(mg *vpcv1alpha1.VPC) {
+				mg.Spec.ForProvider.Description = new(string)
+				*mg.Spec.ForProvider.Description = "observed"
+				mg.Status.AtProvider.ID = "vpc-a"
+			},
+			o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ResourceLateInitialized: true},
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				action:     ActionUpdate,
+				changes:    "the external resource is not up to date; would late initialize spec.forProvider",
+				externalID: "vpc-a",
+				observedID: "vpc-a",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &vpcv1alpha1.VPC{}
+			mg.Spec.ForProvider.Name = "a"
+			mg.Spec.ForProvider.CIDR = "10.0.0.0/16"
+			meta.SetExternalName(mg, tc.externalID)
+			c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+						tc.observe(mg.(*vpcv1alpha1.VPC))
+						return tc.o, nil
+					},
+				}, nil
+			}), true, &recorder{})
+
+			ec, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := ec.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cond := mg.GetCondition(TypePlanned)
+			got := want{
+				o:           o,
+				action:      cond.Reason,
+				changes:     cond.Message,
+				externalID:  meta.GetExternalName(mg),
+				description: mg.Spec.ForProvider.Description,
+				observedID:  mg.Status.AtProvider.ID,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	calls := 0
+	c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			DeleteFn: func(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+				calls++
+				return managed.ExternalDelete{}, nil
+			},
+		}, nil
+	}), true, &recorder{})
+
+	mg := managedWith(nil)
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+
+	ec, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ec.Delete(context.Background(), mg); err == nil {
+		t.Errorf("e.Delete(...): want error in plan mode, got nil")
+	}
+	if calls != 0 {
+		t.Errorf("e.Delete(...): want no deletion in plan mode, got %d", calls)
+	}
+	if diff := cmp.Diff(ActionDelete, mg.GetCondition(TypePlanned).Reason); diff != "" {
+		t.Errorf("e.Delete(...): -want action, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,93 @@
+// Package protection prevents the deletion of external resources whose
+// managed resources are protected.
+package protection
+
+import (
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationDeletionProtection protects a managed resource from deletion if
+// its value is true. Its value is parsed as a bool and takes precedence over
+// the deletionProtection default of the ProviderConfig, so setting it to false
+// lifts the protection of a single managed resource.
+const AnnotationDeletionProtection = "opentelekomcloud.crossplane.io/deletion-protection"
+
+const (
+	// TypeDeletionProtected managed resources whose deletion was refused
+	// because they are protected.
+	TypeDeletionProtected xpv1.ConditionType = "DeletionProtected"
+
+	// ReasonDeletionRefused indicates that the deletion of the external
+	// resource was refused.
+	ReasonDeletionRefused xpv1.ConditionReason = "DeletionRefused"
+
+	// ReasonDeletionAllowed indicates that the external resource is no
+	// longer protected and is being deleted.
+	ReasonDeletionAllowed xpv1.ConditionReason = "DeletionAllowed"
+)
+
+const errProtected = "deletion protection is enabled, set the " + AnnotationDeletionProtection +
+	" annotation to false to delete the external resource"
+
+// Enabled returns true if the supplied managed resource is protected from
+// deletion, given the default of its ProviderConfig.
+func Enabled(mg metav1.Object, def bool) bool {
+	v, ok := mg.GetAnnotations()[AnnotationDeletionProtection]
+	if !ok {
+		return def
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		// Fail safe, a typo must not lift the protection.
+		return true
+	}
+	return enabled
+}
+
+// Refused returns a condition that indicates that the deletion of an external
+// resource was refused because its managed resource is protected.
+func Refused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionRefused,
+		Message:            errProtected,
+	}
+}
+
+// Allowed returns a condition that indicates that the deletion of an external
+// resource proceeds because its managed resource is not protected.
+func Allowed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionAllowed,
+	}
+}
+
+// Refuse sets the DeletionProtected condition of the supplied managed
+// resource and returns the error to return from Delete.
+func Refuse(mg resource.Conditioned) error {
+	mg.SetConditions(Refused())
+	return errors.New(errProtected)
+}
+
+// Check returns the error to return from Delete if the supplied managed
+// resource is protected from deletion, given the default of its
+// ProviderConfig. Otherwise it resolves the DeletionProtected condition, so
+// that a refusal is not reported once the protection was lifted.
+func Check(mg resource.Managed, def bool) error {
+	if Enabled(mg, def) {
+		return Refuse(mg)
+	}
+	mg.SetConditions(Allowed())
+	return nil
+}
@@ -2,10 +2,8 @@ package securitygroup
 
 import (
 	"context"
-	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -14,6 +12,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/vpc/v3/security/group"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,7 +22,20 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/securitygroup/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/adopt"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/enterprise"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/importer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/tagging"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -36,10 +48,16 @@ const (
 	errCreate           = "cannot create SecurityGroup"
 	errUpdate           = "cannot update SecurityGroup"
 	errDelete           = "cannot delete SecurityGroup"
+	errAdopt            = "cannot look up existing SecurityGroups"
+	errImport           = "cannot import SecurityGroup"
+	errUpdateTags       = "cannot update SecurityGroup tags"
 )
 
+// listLimit is the maximum page size of the security group list API.
+const listLimit = 2000
+
 // SetupGated adds a controller that reconciles SecurityGroup managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup SecurityGroup controller"))
@@ -49,25 +67,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles SecurityGroup managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.SecurityGroupGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -98,22 +112,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(
-		mgr,
-		resource.ManagedKind(v1alpha1.SecurityGroupGroupVersionKind),
-		opts...)
+	kind := resource.ManagedKind(v1alpha1.SecurityGroupGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
+	r = dependency.NewReconciler(mgr.GetClient(), kind, r, o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.SecurityGroup{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -145,14 +158,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -169,12 +182,54 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: vpcClient}, nil
+	return &external{
+		client:             vpcClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+		defaultTags:        spec.DefaultTags,
+		namePrefix:         pointer.Deref(spec.NamePrefix, ""),
+		enterpriseProject:  pointer.Deref(spec.EnterpriseProjectID, ""),
+		migrator:           enterprise.NewMigrator(providerClient.NewEPSClient, providerClient.Region),
+	}, nil
 }
 
 // external implements managed.ExternalClient for SecurityGroup resources.
 type external struct {
 	client *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
+
+	// defaultTags are the default tags of the ProviderConfig.
+	defaultTags map[string]string
+
+	// namePrefix is the name prefix of the ProviderConfig.
+	namePrefix string
+
+	// enterpriseProject is the enterprise project default of the
+	// ProviderConfig.
+	enterpriseProject string
+
+	// migrator migrates security groups between enterprise projects.
+	migrator *enterprise.Migrator
+}
+
+// desiredTags returns the desired tags of the supplied security group, including the
+// default tags of the ProviderConfig.
+func (e *external) desiredTags(spec *v1alpha1.SecurityGroupParameters) map[string]string {
+	return tagging.Merge(e.defaultTags, spec.Tags)
+}
+
+// name returns the name of the supplied security group, including the name prefix
+// of the ProviderConfig.
+func (e *external) name(spec *v1alpha1.SecurityGroupParameters) string {
+	return e.namePrefix + spec.Name
+}
+
+// enterpriseProjectID returns the enterprise project of the supplied security
+// group, defaulting to the enterprise project of the ProviderConfig.
+func (e *external) enterpriseProjectID(spec *v1alpha1.SecurityGroupParameters) string {
+	return enterprise.ProjectID(spec.EnterpriseProjectID, e.enterpriseProject)
 }
 
 func (e *external) Observe(
@@ -187,6 +242,17 @@ func (e *external) Observe(
 	}
 
 	externalName := meta.GetExternalName(cr)
+	imported := false
+	if externalName == "" && cr.Spec.ImportSelector != nil {
+		id, err := e.resolveImport(cr.Spec.ImportSelector)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errImport)
+		}
+		// Reporting the managed resource as late initialized persists the
+		// pinned external name.
+		meta.SetExternalName(cr, id)
+		externalName, imported = id, true
+	}
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -200,35 +266,80 @@ func (e *external) Observe(
 		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
 	}
 
+	managedTags := tagging.Managed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags, sg.Tags)
+
 	// Update observed state
 	cr.Status.AtProvider = v1alpha1.SecurityGroupObservation{
-		ID:     sg.ID,
-		Status: "ACTIVE", // Default to ACTIVE if exists
+		ID:                  sg.ID,
+		Status:              "ACTIVE", // Default to ACTIVE if exists
+		Tags:                managedTags,
+		EnterpriseProjectID: sg.EnterpriseProjectID,
 	}
 
 	// Set conditions
 	cr.SetConditions(xpv1.Available())
 
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, sg)
+	diff := e.detectDrift(&cr.Spec.ForProvider, sg, managedTags)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: !needsUpdate,
+		ResourceExists:          true,
+		ResourceUpToDate:        diff.Empty(),
+		ResourceLateInitialized: imported,
 	}, nil
 }
 
-func (e *external) detectDrift(
-	spec *v1alpha1.SecurityGroupParameters,
-	actual *group.SecurityGroup,
-) bool {
-	if actual.Name != spec.Name {
-		return true
+// resolveImport returns the ID of the only security group that matches the
+// supplied import selector.
+func (e *external) resolveImport(s *v1alpha1.SecurityGroupImportSelector) (string, error) {
+	var opts group.ListQueryParams
+	if s.Name != nil {
+		opts.Name = []string{*s.Name}
+	}
+	sgs, err := e.list(opts)
+	if err != nil {
+		return "", err
 	}
-	if pointer.Deref(spec.Description, actual.Description) != actual.Description {
-		return true
+
+	ids := make([]string, len(sgs))
+	byID := make(map[string][]tags.ResourceTag, len(sgs))
+	for i, sg := range sgs {
+		ids[i] = sg.ID
+		byID[sg.ID] = sg.Tags
 	}
+	return importer.Select(ids, s.Tags, func(id string) ([]tags.ResourceTag, error) {
+		return byID[id], nil
+	})
+}
 
-	return false
+// list returns all security groups that match the supplied query.
+func (e *external) list(opts group.ListQueryParams) ([]group.SecurityGroupListObject, error) {
+	opts.Limit = listLimit
+	var sgs []group.SecurityGroupListObject
+	for {
+		res, err := group.List(e.client, opts)
+		if err != nil {
+			return nil, err
+		}
+		sgs = append(sgs, res.SecurityGroups...)
+		if len(res.SecurityGroups) < listLimit || res.PageInfo.NextMarker == "" {
+			return sgs, nil
+		}
+		opts.Marker = res.PageInfo.NextMarker
+	}
+}
+
+func (e *external) detectDrift(
+	spec *v1alpha1.SecurityGroupParameters,
+	actual *group.SecurityGroup,
+	managedTags map[string]string,
+) drift.Diff {
+	var d drift.Diff
+	d.Compare("spec.forProvider.name", e.name(spec), actual.Name)
+	d.Compare("spec.forProvider.description", pointer.Deref(spec.Description, actual.Description), actual.Description)
+	enterprise.Compare(&d, "spec.forProvider.enterpriseProjectId", e.enterpriseProjectID(spec), actual.EnterpriseProjectID)
+	tagging.Compare(&d, "spec.forProvider.tags", e.desiredTags(spec), managedTags)
+	return d
 }
 
 func (e *external) Create(
@@ -242,9 +353,20 @@ func (e *external) Create(
 
 	cr.SetConditions(xpv1.Creating())
 
+	id, err := e.adopt(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if id != "" {
+		meta.SetExternalName(cr, id)
+		return managed.ExternalCreation{}, nil
+	}
+
 	opts := group.CreateOpts{
 		SecurityGroup: group.SecurityGroupOptions{
-			Name: cr.Spec.ForProvider.Name,
+			Name:                e.name(&cr.Spec.ForProvider),
+			Tags:                append(adopt.Tag(cr), tagging.ResourceTags(e.desiredTags(&cr.Spec.ForProvider))...),
+			EnterpriseProjectId: e.enterpriseProjectID(&cr.Spec.ForProvider),
 		},
 	}
 
@@ -263,6 +385,25 @@ func (e *external) Create(
 	return managed.ExternalCreation{}, nil
 }
 
+// adopt returns the ID of an existing security group with the name of the
+// supplied SecurityGroup that carries its UID tag or its desired tags, or an
+// empty string if there is none. Security groups
+// are tagged with the UID of their managed resource on creation.
+func (e *external) adopt(cr *v1alpha1.SecurityGroup) (string, error) {
+	sgs, err := e.list(group.ListQueryParams{
+		Name: []string{e.name(&cr.Spec.ForProvider)},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errAdopt)
+	}
+
+	cs := make([]adopt.Candidate, len(sgs))
+	for i, sg := range sgs {
+		cs[i] = adopt.Candidate{ID: sg.ID, Tags: sg.Tags}
+	}
+	return adopt.Select(cr, e.desiredTags(&cr.Spec.ForProvider), cs)
+}
+
 func (e *external) Update(
 	ctx context.Context,
 	mg resource.Managed,
@@ -274,9 +415,15 @@ func (e *external) Update(
 
 	externalName := meta.GetExternalName(cr)
 
+	if ep := e.enterpriseProjectID(&cr.Spec.ForProvider); enterprise.NeedsMigration(ep, cr.Status.AtProvider.EnterpriseProjectID) {
+		if err := e.migrator.Migrate(enterprise.ResourceTypeSecurityGroup, externalName, ep); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
 	opts := group.UpdateOpts{
 		SecurityGroup: group.SecurityGroupUpdateOptions{
-			Name: cr.Spec.ForProvider.Name,
+			Name: e.name(&cr.Spec.ForProvider),
 		},
 	}
 
@@ -289,9 +436,40 @@ func (e *external) Update(
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 	}
 
+	upsert, remove := tagging.Changes(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags)
+	if err := e.updateTags(externalName, upsert, remove); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
+// updateTags creates or overwrites the upsert tags and deletes the remove tags
+// of the security group with the supplied ID. Security group tags are managed
+// through the VPC V3 API, which the common tag API does not cover.
+func (e *external) updateTags(id string, upsert, remove []tags.ResourceTag) error {
+	for _, a := range []struct {
+		action string
+		tags   []tags.ResourceTag
+	}{
+		{action: "create", tags: upsert},
+		{action: "delete", tags: remove},
+	} {
+		if len(a.tags) == 0 {
+			continue
+		}
+		// POST /v3/{project_id}/vpc/security-groups/{security_group_id}/tags/{action}
+		_, err := e.client.Post(e.client.ServiceURL("security-groups", id, "tags", a.action),
+			map[string]any{"tags": a.tags}, nil, &golangsdk.RequestOpts{
+				OkCodes: []int{200, 204},
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *external) Delete(
 	ctx context.Context,
 	mg resource.Managed,
@@ -301,6 +479,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotSecurityGroup)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -308,7 +490,7 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, nil
 	}
 
-	err := group.Delete(e.client, externalName)
+	err := dependency.Wait(cr, group.Delete(e.client, externalName), "ports", "security group rules")
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
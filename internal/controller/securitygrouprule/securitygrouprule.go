@@ -2,10 +2,8 @@ package securitygrouprule
 
 import (
 	"context"
-	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -23,7 +21,15 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/securitygrouprule/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -39,7 +45,7 @@ const (
 )
 
 // SetupGated adds a controller that reconciles SecurityGroupRule managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup SecurityGroupRule controller"))
@@ -49,25 +55,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles SecurityGroupRule managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.SecurityGroupRuleGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -98,22 +100,20 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(
-		mgr,
-		resource.ManagedKind(v1alpha1.SecurityGroupRuleGroupVersionKind),
-		opts...)
+	kind := resource.ManagedKind(v1alpha1.SecurityGroupRuleGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.SecurityGroupRule{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -145,14 +145,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -169,12 +169,19 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: vpcClient}, nil
+	return &external{
+		client:             vpcClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+	}, nil
 }
 
 // external implements managed.ExternalClient for SecurityGroupRule resources.
 type external struct {
 	client *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
 }
 
 func (e *external) Observe(
@@ -210,11 +217,12 @@ func (e *external) Observe(
 	cr.SetConditions(xpv1.Available())
 
 	lateInitialized := e.detectLateInitialization(&cr.Spec.ForProvider, rule)
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, rule)
+	diff := e.detectDrift(&cr.Spec.ForProvider, rule)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        !needsUpdate,
+		ResourceUpToDate:        diff.Empty(),
 		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
@@ -268,49 +276,27 @@ func (e *external) detectLateInitialization(
 	return initialized
 }
 
-//nolint:gocyclo
 func (e *external) detectDrift(
 	spec *v1alpha1.SecurityGroupRuleParameters,
 	actual *rules.SecurityGroupRule,
-) bool {
-	if actual.SecurityGroupID != spec.SecurityGroupID {
-		return true
-	}
-	if actual.Direction != spec.Direction {
-		return true
-	}
-	if pointer.Deref(spec.Description, actual.Description) != actual.Description {
-		return true
-	}
-	if pointer.Deref(spec.Ethertype, actual.Ethertype) != actual.Ethertype {
-		return true
-	}
-	if pointer.Deref(spec.Protocol, actual.Protocol) != actual.Protocol {
-		return true
-	}
-	if pointer.Deref(spec.Multiport, actual.Multiport) != actual.Multiport {
-		return true
-	}
-	if pointer.Deref(spec.RemoteIPPrefix, actual.RemoteIPPrefix) != actual.RemoteIPPrefix {
-		return true
-	}
-	if pointer.Deref(spec.RemoteGroupID, actual.RemoteGroupID) != actual.RemoteGroupID {
-		return true
-	}
-	if pointer.Deref(
-		spec.RemoteAddressGroupID,
-		actual.RemoteAddressGroupID,
-	) != actual.RemoteAddressGroupID {
-		return true
-	}
-	if pointer.Deref(spec.Action, actual.Action) != actual.Action {
-		return true
-	}
-	if pointer.Deref(spec.Priority, actual.Priority) != actual.Priority {
-		return true
-	}
-
-	return false
+) drift.Diff {
+	var d drift.Diff
+	d.CompareImmutable("spec.forProvider.securityGroupId", spec.SecurityGroupID, actual.SecurityGroupID)
+	d.CompareImmutable("spec.forProvider.direction", spec.Direction, actual.Direction)
+	// Rules cannot be updated at all, not even their description.
+	d.CompareImmutable("spec.forProvider.description", pointer.Deref(spec.Description, actual.Description), actual.Description)
+	d.CompareImmutable("spec.forProvider.ethertype", pointer.Deref(spec.Ethertype, actual.Ethertype), actual.Ethertype)
+	d.CompareImmutable("spec.forProvider.protocol", pointer.Deref(spec.Protocol, actual.Protocol), actual.Protocol)
+	d.CompareImmutable("spec.forProvider.multiport", pointer.Deref(spec.Multiport, actual.Multiport), actual.Multiport)
+	d.CompareImmutable("spec.forProvider.remoteIpPrefix",
+		pointer.Deref(spec.RemoteIPPrefix, actual.RemoteIPPrefix), actual.RemoteIPPrefix)
+	d.CompareImmutable("spec.forProvider.remoteGroupId",
+		pointer.Deref(spec.RemoteGroupID, actual.RemoteGroupID), actual.RemoteGroupID)
+	d.CompareImmutable("spec.forProvider.remoteAddressGroupId",
+		pointer.Deref(spec.RemoteAddressGroupID, actual.RemoteAddressGroupID), actual.RemoteAddressGroupID)
+	d.CompareImmutable("spec.forProvider.action", pointer.Deref(spec.Action, actual.Action), actual.Action)
+	d.CompareImmutable("spec.forProvider.priority", pointer.Deref(spec.Priority, actual.Priority), actual.Priority)
+	return d
 }
 
 //nolint:gocyclo
@@ -374,7 +360,7 @@ func (e *external) Update(
 ) (managed.ExternalUpdate, error) {
 	// Security Group Rules are immutable. Any detected drift requires
 	// recreation, so we return an error.
-	return managed.ExternalUpdate{}, errors.New(errImmutable)
+	return managed.ExternalUpdate{}, drift.ErrImmutable(mg, errImmutable)
 }
 
 func (e *external) Delete(
@@ -386,6 +372,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotSecurityGroupRule)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -2,10 +2,8 @@ package snatrule
 
 import (
 	"context"
-	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -23,7 +21,15 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/snatrule/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -39,7 +45,7 @@ const (
 )
 
 // SetupGated adds a controller that reconciles SNATRule managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup SNATRule controller"))
@@ -49,25 +55,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles SNATRule managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.SNATRuleGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -98,22 +100,20 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(
-		mgr,
-		resource.ManagedKind(v1alpha1.SNATRuleGroupVersionKind),
-		opts...)
+	kind := resource.ManagedKind(v1alpha1.SNATRuleGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.SNATRule{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -145,14 +145,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -169,12 +169,19 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: networkClient}, nil
+	return &external{
+		client:             networkClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+	}, nil
 }
 
 // external implements managed.ExternalClient for SNATRule resources.
 type external struct {
 	client *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
 }
 
 func (e *external) Observe(
@@ -223,32 +230,25 @@ func (e *external) Observe(
 		cr.SetConditions(xpv1.Unavailable())
 	}
 
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, rule)
+	diff := e.detectDrift(&cr.Spec.ForProvider, rule)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: !needsUpdate,
+		ResourceUpToDate: diff.Empty(),
 	}, nil
 }
 
 func (e *external) detectDrift(
 	spec *v1alpha1.SNATRuleParameters,
 	actual *snatrules.SnatRule,
-) bool {
-	if actual.NatGatewayID != spec.NATGatewayID {
-		return true
-	}
-	if actual.FloatingIPID != spec.ElasticIPID {
-		return true
-	}
-	if pointer.Deref(spec.SubnetID, actual.NetworkID) != actual.NetworkID {
-		return true
-	}
-	if pointer.Deref(spec.CIDR, actual.Cidr) != actual.Cidr {
-		return true
-	}
-
-	return false
+) drift.Diff {
+	var d drift.Diff
+	d.CompareImmutable("spec.forProvider.natGatewayId", spec.NATGatewayID, actual.NatGatewayID)
+	d.CompareImmutable("spec.forProvider.elasticIpId", spec.ElasticIPID, actual.FloatingIPID)
+	d.CompareImmutable("spec.forProvider.subnetId", pointer.Deref(spec.SubnetID, actual.NetworkID), actual.NetworkID)
+	d.CompareImmutable("spec.forProvider.cidr", pointer.Deref(spec.CIDR, actual.Cidr), actual.Cidr)
+	return d
 }
 
 func (e *external) Create(
@@ -298,7 +298,7 @@ func (e *external) Update(
 ) (managed.ExternalUpdate, error) {
 	// SNAT Rules are immutable. Any detected drift requires recreation, so we
 	// return an error.
-	return managed.ExternalUpdate{}, errors.New(errImmutable)
+	return managed.ExternalUpdate{}, drift.ErrImmutable(mg, errImmutable)
 }
 
 func (e *external) Delete(
@@ -310,6 +310,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotSNATRule)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
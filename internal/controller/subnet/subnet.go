@@ -2,10 +2,8 @@ package subnet
 
 import (
 	"context"
-	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -14,6 +12,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/subnets"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,7 +22,19 @@ import (
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/subnet/v1alpha1"
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/adopt"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/importer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/tagging"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -36,10 +47,18 @@ const (
 	errCreate       = "cannot create Subnet"
 	errUpdate       = "cannot update Subnet"
 	errDelete       = "cannot delete Subnet"
+	errAdopt        = "cannot look up existing Subnets"
+	errImport       = "cannot import Subnet"
+	errTag          = "cannot tag Subnet"
+	errObserveTags  = "cannot observe Subnet tags"
+	errUpdateTags   = "cannot update Subnet tags"
 )
 
+// tagResourceType is the resource type of subnets in the tag API.
+const tagResourceType = "subnets"
+
 // SetupGated adds a controller that reconciles Subnet managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup Subnet controller"))
@@ -49,25 +68,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles Subnet managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.SubnetGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -98,19 +113,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SubnetGroupVersionKind), opts...)
+	kind := resource.ManagedKind(v1alpha1.SubnetGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
+	r = dependency.NewReconciler(mgr.GetClient(), kind, r, o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Subnet{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -142,14 +159,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -166,12 +183,47 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: networkClient}, nil
+	// Subnet tags are only available in the Network V2 API
+	tagClient, err := providerClient.NewNetworkV2Client()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		client:             networkClient,
+		tagClient:          tagClient,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+		defaultTags:        spec.DefaultTags,
+		namePrefix:         pointer.Deref(spec.NamePrefix, ""),
+	}, nil
 }
 
 // external implements managed.ExternalClient for Subnet resources.
 type external struct {
-	client *golangsdk.ServiceClient
+	client    *golangsdk.ServiceClient
+	tagClient *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
+
+	// defaultTags are the default tags of the ProviderConfig.
+	defaultTags map[string]string
+
+	// namePrefix is the name prefix of the ProviderConfig.
+	namePrefix string
+}
+
+// desiredTags returns the desired tags of the supplied subnet, including the
+// default tags of the ProviderConfig.
+func (e *external) desiredTags(spec *v1alpha1.SubnetParameters) map[string]string {
+	return tagging.Merge(e.defaultTags, spec.Tags)
+}
+
+// name returns the name of the supplied subnet, including the name prefix
+// of the ProviderConfig.
+func (e *external) name(spec *v1alpha1.SubnetParameters) string {
+	return e.namePrefix + spec.Name
 }
 
 func (e *external) Observe(
@@ -184,6 +236,17 @@ func (e *external) Observe(
 	}
 
 	externalName := meta.GetExternalName(cr)
+	imported := false
+	if externalName == "" && cr.Spec.ImportSelector != nil {
+		id, err := e.resolveImport(cr.Spec.ImportSelector)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errImport)
+		}
+		// Reporting the managed resource as late initialized persists the
+		// pinned external name.
+		meta.SetExternalName(cr, id)
+		externalName, imported = id, true
+	}
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -197,12 +260,25 @@ func (e *external) Observe(
 		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
 	}
 
+	var managedTags map[string]string
+	if tagging.Needed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags) {
+		ts, err := e.getTags(subnet.ID)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveTags)
+		}
+		managedTags = tagging.Managed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags, ts)
+	}
+
 	// Update observed state
 	cr.Status.AtProvider = v1alpha1.SubnetObservation{
-		ID:     subnet.ID,
-		Status: subnet.Status,
-		CIDR:   subnet.CIDR,
-		VPCID:  subnet.VpcID,
+		ID:            subnet.ID,
+		Status:        subnet.Status,
+		CIDR:          subnet.CIDR,
+		GatewayIP:     subnet.GatewayIP,
+		VPCID:         subnet.VpcID,
+		IPv6CIDR:      subnet.CidrV6,
+		IPv6GatewayIP: subnet.GatewayIpV6,
+		Tags:          managedTags,
 	}
 
 	// Set conditions based on status
@@ -216,15 +292,35 @@ func (e *external) Observe(
 	}
 
 	lateInitialized := e.detectLateInitialization(&cr.Spec.ForProvider, subnet)
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, subnet)
+	diff := e.detectDrift(&cr.Spec.ForProvider, subnet, managedTags)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        !needsUpdate,
-		ResourceLateInitialized: lateInitialized,
+		ResourceUpToDate:        diff.Empty(),
+		ResourceLateInitialized: lateInitialized || imported,
 	}, nil
 }
 
+// resolveImport returns the ID of the only subnet that matches the supplied
+// import selector.
+func (e *external) resolveImport(s *v1alpha1.SubnetImportSelector) (string, error) {
+	ss, err := subnets.List(e.client, subnets.ListOpts{
+		Name:  pointer.Deref(s.Name, ""),
+		CIDR:  pointer.Deref(s.CIDR, ""),
+		VpcID: pointer.Deref(s.VPCID, ""),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ids := make([]string, len(ss))
+	for i, sn := range ss {
+		ids[i] = sn.ID
+	}
+	return importer.Select(ids, s.Tags, e.getTags)
+}
+
 // detectLateInitialization fills optional Spec fields if they are empty but present at the provider.
 func (e *external) detectLateInitialization(
 	spec *v1alpha1.SubnetParameters,
@@ -236,6 +332,10 @@ func (e *external) detectLateInitialization(
 		spec.DHCPEnable = pointer.To(actual.EnableDHCP)
 		initialized = true
 	}
+	if spec.IPv6Enable == nil {
+		spec.IPv6Enable = pointer.To(actual.EnableIpv6)
+		initialized = true
+	}
 
 	if spec.PrimaryDNS == nil && actual.PrimaryDNS != "" {
 		spec.PrimaryDNS = pointer.To(actual.PrimaryDNS)
@@ -253,30 +353,22 @@ func (e *external) detectLateInitialization(
 	return initialized
 }
 
-func (e *external) detectDrift(spec *v1alpha1.SubnetParameters, actual *subnets.Subnet) bool {
-	if actual.Name != spec.Name {
-		return true
-	}
-	if actual.CIDR != spec.CIDR {
-		return true
-	}
-	if actual.GatewayIP != spec.GatewayIP {
-		return true
-	}
-	if pointer.Deref(spec.Description, actual.Description) != actual.Description {
-		return true
-	}
-	if pointer.Deref(spec.DHCPEnable, actual.EnableDHCP) != actual.EnableDHCP {
-		return true
-	}
-	if pointer.Deref(spec.PrimaryDNS, actual.PrimaryDNS) != actual.PrimaryDNS {
-		return true
-	}
-	if pointer.Deref(spec.SecondaryDNS, actual.SecondaryDNS) != actual.SecondaryDNS {
-		return true
-	}
-
-	return false
+func (e *external) detectDrift(
+	spec *v1alpha1.SubnetParameters,
+	actual *subnets.Subnet,
+	managedTags map[string]string,
+) drift.Diff {
+	var d drift.Diff
+	d.Compare("spec.forProvider.name", e.name(spec), actual.Name)
+	d.CompareImmutable("spec.forProvider.cidr", spec.CIDR, actual.CIDR)
+	d.CompareImmutable("spec.forProvider.gatewayIp", spec.GatewayIP, actual.GatewayIP)
+	d.Compare("spec.forProvider.description", pointer.Deref(spec.Description, actual.Description), actual.Description)
+	d.Compare("spec.forProvider.dhcpEnable", pointer.Deref(spec.DHCPEnable, actual.EnableDHCP), actual.EnableDHCP)
+	d.Compare("spec.forProvider.ipv6Enable", pointer.Deref(spec.IPv6Enable, actual.EnableIpv6), actual.EnableIpv6)
+	d.Compare("spec.forProvider.primaryDns", pointer.Deref(spec.PrimaryDNS, actual.PrimaryDNS), actual.PrimaryDNS)
+	d.Compare("spec.forProvider.secondaryDns", pointer.Deref(spec.SecondaryDNS, actual.SecondaryDNS), actual.SecondaryDNS)
+	tagging.Compare(&d, "spec.forProvider.tags", e.desiredTags(spec), managedTags)
+	return d
 }
 
 func (e *external) Create(
@@ -290,8 +382,17 @@ func (e *external) Create(
 
 	cr.SetConditions(xpv1.Creating())
 
+	id, err := e.adopt(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if id != "" {
+		meta.SetExternalName(cr, id)
+		return managed.ExternalCreation{}, nil
+	}
+
 	opts := subnets.CreateOpts{
-		Name:      cr.Spec.ForProvider.Name,
+		Name:      e.name(&cr.Spec.ForProvider),
 		CIDR:      cr.Spec.ForProvider.CIDR,
 		GatewayIP: cr.Spec.ForProvider.GatewayIP,
 		VpcID:     cr.Spec.ForProvider.VPCID,
@@ -300,6 +401,9 @@ func (e *external) Create(
 	if cr.Spec.ForProvider.DHCPEnable != nil {
 		opts.EnableDHCP = cr.Spec.ForProvider.DHCPEnable
 	}
+	if cr.Spec.ForProvider.IPv6Enable != nil {
+		opts.EnableIpv6 = cr.Spec.ForProvider.IPv6Enable
+	}
 	if cr.Spec.ForProvider.PrimaryDNS != nil {
 		opts.PrimaryDNS = *cr.Spec.ForProvider.PrimaryDNS
 	}
@@ -321,9 +425,63 @@ func (e *external) Create(
 	// Set external name to the subnet ID
 	meta.SetExternalName(cr, subnet.ID)
 
+	if err := e.tag(cr, subnet.ID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{}, nil
 }
 
+// adopt returns the ID of an existing subnet with the name and CIDR of the
+// supplied Subnet in its VPC that carries its UID tag, its desired tags or no
+// tags at all, or an empty string if there is none. Subnets cannot be tagged
+// on creation, so a subnet that was created but not tagged yet is matched by
+// name and CIDR. An adopted subnet is tagged with the UID of the managed
+// resource.
+func (e *external) adopt(cr *v1alpha1.Subnet) (string, error) {
+	candidates, err := subnets.List(e.client, subnets.ListOpts{
+		Name:  e.name(&cr.Spec.ForProvider),
+		CIDR:  cr.Spec.ForProvider.CIDR,
+		VpcID: cr.Spec.ForProvider.VPCID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errAdopt)
+	}
+
+	cs := make([]adopt.Candidate, len(candidates))
+	for i, c := range candidates {
+		ts, err := e.getTags(c.ID)
+		if err != nil {
+			return "", errors.Wrap(err, errAdopt)
+		}
+		cs[i] = adopt.Candidate{ID: c.ID, Tags: ts}
+	}
+
+	id, err := adopt.SelectUntagged(cr, e.desiredTags(&cr.Spec.ForProvider), cs)
+	if err != nil || id == "" {
+		return "", err
+	}
+	for _, c := range cs {
+		if c.ID == id && adopt.Tagged(cr, c.Tags) {
+			return id, nil
+		}
+	}
+	return id, e.tag(cr, id)
+}
+
+// getTags returns the tags of the subnet with the supplied ID.
+func (e *external) getTags(id string) ([]tags.ResourceTag, error) {
+	return tags.Get(e.tagClient, tagResourceType, id).Extract()
+}
+
+// tag stamps the UID of the supplied managed resource and its desired tags on
+// the subnet with the supplied ID. Subnets cannot be tagged on creation, so a
+// subnet whose UID tag is missing is found by name and CIDR.
+func (e *external) tag(cr *v1alpha1.Subnet, id string) error {
+	t := append(adopt.Tag(cr), tagging.ResourceTags(e.desiredTags(&cr.Spec.ForProvider))...)
+	return errors.Wrap(tagging.Apply(e.tagClient, tagResourceType, id, t, nil), errTag)
+}
+
 func (e *external) Update(
 	ctx context.Context,
 	mg resource.Managed,
@@ -334,25 +492,26 @@ func (e *external) Update(
 	}
 
 	// Verify immutable fields
-	if cr.Spec.ForProvider.VPCID != cr.Status.AtProvider.VPCID {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: VPCID")
-	}
-	if cr.Spec.ForProvider.CIDR != cr.Status.AtProvider.CIDR {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: CIDR")
-	}
-	if cr.Spec.ForProvider.GatewayIP != cr.Status.AtProvider.GatewayIP {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: GatewayIP")
+	var immutable drift.Diff
+	immutable.CompareImmutable("spec.forProvider.vpcId", cr.Spec.ForProvider.VPCID, cr.Status.AtProvider.VPCID)
+	immutable.CompareImmutable("spec.forProvider.cidr", cr.Spec.ForProvider.CIDR, cr.Status.AtProvider.CIDR)
+	immutable.CompareImmutable("spec.forProvider.gatewayIp", cr.Spec.ForProvider.GatewayIP, cr.Status.AtProvider.GatewayIP)
+	if err := immutable.Err(); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	externalName := meta.GetExternalName(cr)
 
 	opts := subnets.UpdateOpts{
-		Name: cr.Spec.ForProvider.Name,
+		Name: e.name(&cr.Spec.ForProvider),
 	}
 
 	if cr.Spec.ForProvider.DHCPEnable != nil {
 		opts.EnableDHCP = cr.Spec.ForProvider.DHCPEnable
 	}
+	if cr.Spec.ForProvider.IPv6Enable != nil {
+		opts.EnableIpv6 = cr.Spec.ForProvider.IPv6Enable
+	}
 	if cr.Spec.ForProvider.PrimaryDNS != nil {
 		opts.PrimaryDNS = *cr.Spec.ForProvider.PrimaryDNS
 	}
@@ -368,6 +527,11 @@ func (e *external) Update(
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 	}
 
+	upsert, remove := tagging.Changes(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags)
+	if err := tagging.Apply(e.tagClient, tagResourceType, externalName, upsert, remove); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -380,6 +544,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotSubnet)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -387,7 +555,7 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, nil
 	}
 
-	err := subnets.Delete(e.client, cr.Spec.ForProvider.VPCID, externalName).ExtractErr()
+	err := dependency.Wait(cr, subnets.Delete(e.client, cr.Spec.ForProvider.VPCID, externalName).ExtractErr(), "ports", "NAT gateways", "SNAT rules")
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
@@ -50,6 +50,12 @@ func withDHCPOption(enable bool) params {
 	}
 }
 
+func withIPv6Option(enable bool) params {
+	return func(s *v1alpha1.Subnet) {
+		s.Spec.ForProvider.IPv6Enable = pointer.To(enable)
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		handler http.HandlerFunc
@@ -133,6 +139,7 @@ func TestObserve(t *testing.T) {
 					withExternalName("subnet-id-123"),
 					withSpec("test-subnet", "192.168.1.0/24", "192.168.1.1", "vpc-123"),
 					withDHCPOption(true),
+					withIPv6Option(false),
 				),
 			},
 			want: want{
@@ -171,6 +178,7 @@ func TestObserve(t *testing.T) {
 					withExternalName("subnet-id-123"),
 					withSpec("new-name", "192.168.1.0/24", "192.168.1.1", "vpc-123"),
 					withDHCPOption(false),
+					withIPv6Option(false),
 				),
 			},
 			want: want{
@@ -181,6 +189,46 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"IPv6Drift": {
+			reason: "Should detect drift when IPv6 is enabled in spec but not on the subnet",
+			fields: fields{
+				handler: func(w http.ResponseWriter, r *http.Request) {
+					testhelper.TestMethod(t, r, "GET")
+					w.Header().Add("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+
+					fmt.Fprintf(w, `
+						{
+							"subnet": {
+								"id": "subnet-id-123",
+								"name": "test-subnet",
+								"cidr": "192.168.1.0/24",
+								"gateway_ip": "192.168.1.1",
+								"vpc_id": "vpc-123",
+								"status": "ACTIVE",
+								"dhcp_enable": true,
+								"ipv6_enable": false
+							}
+						}
+					`)
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: subnet(
+					withExternalName("subnet-id-123"),
+					withSpec("test-subnet", "192.168.1.0/24", "192.168.1.1", "vpc-123"),
+					withDHCPOption(true),
+					withIPv6Option(true),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
 		"LateInitialization": {
 			reason: "Should populate optional fields (Late Init) if missing in Spec but present in Cloud",
 			fields: fields{
@@ -259,3 +307,94 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	type fields struct {
+		// subnets are the existing subnets and their tags.
+		subnets map[string]string
+	}
+
+	type want struct {
+		externalName string
+		created      bool
+		tagged       []string
+		err          bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"Create": {
+			reason: "Should create and tag a subnet if there is none to adopt",
+			want: want{
+				externalName: "subnet-new",
+				created:      true,
+				tagged:       []string{"subnet-new"},
+			},
+		},
+		"Untagged": {
+			reason: "Should adopt and tag a single subnet with the same name and CIDR that was created but not tagged yet",
+			fields: fields{subnets: map[string]string{"subnet-a": `{"tags":[]}`}},
+			want: want{
+				externalName: "subnet-a",
+				tagged:       []string{"subnet-a"},
+			},
+		},
+		"Mislabelled": {
+			reason: "Should report a single subnet with the same name and CIDR that carries other tags instead of adopting it",
+			fields: fields{subnets: map[string]string{"subnet-a": `{"tags":[{"key":"team","value":"storage"}]}`}},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			got := want{}
+			testhelper.Mux.HandleFunc("/project/subnets", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					got.created = true
+					fmt.Fprint(w, `{"subnet":{"id":"subnet-new","name":"test-subnet","cidr":"192.168.1.0/24"}}`)
+					return
+				}
+				subnets := make([]string, 0, len(tc.fields.subnets))
+				for id := range tc.fields.subnets {
+					subnets = append(subnets, fmt.Sprintf(`{"id":%q,"name":"test-subnet","cidr":"192.168.1.0/24","vpc_id":"vpc-id-123"}`, id))
+				}
+				fmt.Fprintf(w, `{"subnets":[%s]}`, strings.Join(subnets, ","))
+			})
+			testhelper.Mux.HandleFunc("/project/subnets/", func(w http.ResponseWriter, r *http.Request) {
+				id := strings.Split(strings.TrimPrefix(r.URL.Path, "/project/subnets/"), "/")[0]
+				if r.Method == http.MethodPost {
+					got.tagged = append(got.tagged, id)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, tc.fields.subnets[id])
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+			sc.ProjectID = "project"
+
+			mg := subnet(withSpec("test-subnet", "192.168.1.0/24", "192.168.1.1", "vpc-id-123"))
+			mg.SetUID("uid-1")
+			e := external{client: sc, tagClient: sc}
+			_, err := e.Create(context.Background(), mg)
+
+			got.externalName = meta.GetExternalName(mg)
+			got.err = err != nil
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
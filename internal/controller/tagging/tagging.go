@@ -0,0 +1,152 @@
+// Package tagging reconciles the tags of external resources with the tags of
+// their managed resources.
+//
+// A managed resource only manages the tag keys it desires, and the keys it
+// desired before, which are recorded in its status. Other tags of its
+// external resource, e.g. tags added by other tools, are never touched.
+package tagging
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+)
+
+// absent is the observed value of a managed tag that the external resource
+// does not carry.
+const absent = "<absent>"
+
+const (
+	errCreateTags = "cannot create tags"
+	errDeleteTags = "cannot delete tags"
+)
+
+// Map returns the supplied tags as a map.
+func Map(ts []tags.ResourceTag) map[string]string {
+	m := make(map[string]string, len(ts))
+	for _, t := range ts {
+		m[t.Key] = t.Value
+	}
+	return m
+}
+
+// Merge returns the supplied default tags overlaid with the supplied tags of a
+// managed resource.
+func Merge(defaults, tags map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return tags
+	}
+	m := make(map[string]string, len(defaults)+len(tags))
+	for k, v := range defaults {
+		m[k] = v
+	}
+	for k, v := range tags {
+		m[k] = v
+	}
+	return m
+}
+
+// ResourceTags returns the supplied map as tags, sorted by key.
+func ResourceTags(m map[string]string) []tags.ResourceTag {
+	if len(m) == 0 {
+		return nil
+	}
+	ts := make([]tags.ResourceTag, 0, len(m))
+	for _, k := range keys(m) {
+		ts = append(ts, tags.ResourceTag{Key: k, Value: m[k]})
+	}
+	return ts
+}
+
+// Needed returns true if the tags of an external resource have to be
+// observed, i.e. if a managed resource desires tags or managed tags before.
+func Needed(desired, managed map[string]string) bool {
+	return len(desired) > 0 || len(managed) > 0
+}
+
+// Managed returns the observed tags whose keys are managed by a managed
+// resource: the keys it desires, and the keys it managed before that the
+// external resource still carries.
+func Managed(desired, before map[string]string, observed []tags.ResourceTag) map[string]string {
+	o := Map(observed)
+	m := map[string]string{}
+	for k := range before {
+		if v, ok := o[k]; ok {
+			m[k] = v
+		}
+	}
+	for k := range desired {
+		if v, ok := o[k]; ok {
+			m[k] = v
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Compare adds the managed tags that differ from the desired tags to the
+// supplied diff. The path is the path of the tags in the managed resource,
+// e.g. spec.forProvider.tags.
+func Compare(d *drift.Diff, path string, desired, managed map[string]string) {
+	for _, k := range keys(desired) {
+		observed, ok := managed[k]
+		if !ok {
+			observed = absent
+		}
+		d.Compare(fmt.Sprintf("%s[%s]", path, k), desired[k], observed)
+	}
+	for _, k := range keys(managed) {
+		if _, ok := desired[k]; !ok {
+			d.Compare(fmt.Sprintf("%s[%s]", path, k), absent, managed[k])
+		}
+	}
+}
+
+// Changes returns the tags to create or overwrite and the tags to delete to
+// turn the managed tags into the desired tags.
+func Changes(desired, managed map[string]string) (upsert, remove []tags.ResourceTag) {
+	for _, k := range keys(desired) {
+		if v, ok := managed[k]; !ok || v != desired[k] {
+			upsert = append(upsert, tags.ResourceTag{Key: k, Value: desired[k]})
+		}
+	}
+	for _, k := range keys(managed) {
+		if _, ok := desired[k]; !ok {
+			remove = append(remove, tags.ResourceTag{Key: k, Value: managed[k]})
+		}
+	}
+	return upsert, remove
+}
+
+// Apply creates or overwrites the upsert tags and deletes the remove tags of
+// the external resource with the supplied ID and resource type through the
+// tag API of the supplied client.
+func Apply(c *golangsdk.ServiceClient, resourceType, id string, upsert, remove []tags.ResourceTag) error {
+	if len(upsert) > 0 {
+		if err := tags.Create(c, resourceType, id, upsert).ExtractErr(); err != nil {
+			return errors.Wrap(err, errCreateTags)
+		}
+	}
+	if len(remove) > 0 {
+		if err := tags.Delete(c, resourceType, id, remove).ExtractErr(); err != nil {
+			return errors.Wrap(err, errDeleteTags)
+		}
+	}
+	return nil
+}
+
+func keys(m map[string]string) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
@@ -0,0 +1,141 @@
+package tagging
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+)
+
+func TestReconcile(t *testing.T) {
+	type args struct {
+		desired  map[string]string
+		before   map[string]string
+		observed []tags.ResourceTag
+	}
+
+	type want struct {
+		managed map[string]string
+		drift   string
+		upsert  []tags.ResourceTag
+		remove  []tags.ResourceTag
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"InSync": {
+			reason: "Should not touch tags that match and ignore tags the managed resource does not manage",
+			args: args{
+				desired:  map[string]string{"env": "prod"},
+				observed: []tags.ResourceTag{{Key: "env", Value: "prod"}, {Key: "owner", Value: "ops"}},
+			},
+			want: want{
+				managed: map[string]string{"env": "prod"},
+			},
+		},
+		"Missing": {
+			reason: "Should create desired tags that the external resource does not carry",
+			args: args{
+				desired:  map[string]string{"env": "prod"},
+				observed: []tags.ResourceTag{{Key: "owner", Value: "ops"}},
+			},
+			want: want{
+				drift:  `spec.forProvider.tags[env]: desired "prod", observed "<absent>"`,
+				upsert: []tags.ResourceTag{{Key: "env", Value: "prod"}},
+			},
+		},
+		"Changed": {
+			reason: "Should overwrite managed tags whose value differs",
+			args: args{
+				desired:  map[string]string{"env": "prod"},
+				before:   map[string]string{"env": "prod"},
+				observed: []tags.ResourceTag{{Key: "env", Value: "dev"}},
+			},
+			want: want{
+				managed: map[string]string{"env": "dev"},
+				drift:   `spec.forProvider.tags[env]: desired "prod", observed "dev"`,
+				upsert:  []tags.ResourceTag{{Key: "env", Value: "prod"}},
+			},
+		},
+		"Removed": {
+			reason: "Should delete tags that were managed before but are no longer desired",
+			args: args{
+				before:   map[string]string{"env": "prod"},
+				observed: []tags.ResourceTag{{Key: "env", Value: "prod"}, {Key: "owner", Value: "ops"}},
+			},
+			want: want{
+				managed: map[string]string{"env": "prod"},
+				drift:   `spec.forProvider.tags[env]: desired "<absent>", observed "prod"`,
+				remove:  []tags.ResourceTag{{Key: "env", Value: "prod"}},
+			},
+		},
+		"RemovedElsewhere": {
+			reason: "Should forget tags that were managed before but are gone",
+			args: args{
+				before:   map[string]string{"env": "prod"},
+				observed: []tags.ResourceTag{{Key: "owner", Value: "ops"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := Managed(tc.args.desired, tc.args.before, tc.args.observed)
+
+			var d drift.Diff
+			Compare(&d, "spec.forProvider.tags", tc.args.desired, m)
+			upsert, remove := Changes(tc.args.desired, m)
+
+			got := want{managed: m, drift: d.String(), upsert: upsert, remove: remove}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nManaged, Compare, Changes: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	type args struct {
+		defaults map[string]string
+		tags     map[string]string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[string]string
+	}{
+		"NoDefaults": {
+			reason: "Should return the tags of the managed resource if there are no defaults",
+			args:   args{tags: map[string]string{"env": "prod"}},
+			want:   map[string]string{"env": "prod"},
+		},
+		"Defaults": {
+			reason: "Should return the defaults if the managed resource has no tags",
+			args:   args{defaults: map[string]string{"owner": "ops"}},
+			want:   map[string]string{"owner": "ops"},
+		},
+		"Override": {
+			reason: "Should let the tags of the managed resource override the defaults",
+			args: args{
+				defaults: map[string]string{"env": "dev", "owner": "ops"},
+				tags:     map[string]string{"env": "prod"},
+			},
+			want: map[string]string{"env": "prod", "owner": "ops"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Merge(tc.args.defaults, tc.args.tags)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMerge(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
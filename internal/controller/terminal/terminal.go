@@ -0,0 +1,174 @@
+// Package terminal stops retrying to create or update external resources
+// once an OTC API rejected the spec of their managed resource for good.
+package terminal
+
+import (
+	"context"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+const (
+	// TypeTerminalError managed resources were rejected by an OTC API in a
+	// way that retrying cannot fix. They are not retried until their spec
+	// changes, except for exhausted quotas, which are retried at the poll
+	// interval since freeing quota elsewhere resolves them.
+	TypeTerminalError xpv1.ConditionType = "TerminalError"
+
+	// ReasonResolved indicates that the last request was not rejected for
+	// good.
+	ReasonResolved xpv1.ConditionReason = "Resolved"
+)
+
+const errBlocked = "not retrying until the spec changes"
+
+// Failed returns a condition that indicates that the supplied OTC API error
+// rejected the supplied generation of a managed resource for good.
+func Failed(err *clients.Error, generation int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTerminalError,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             err.Reason(),
+		Message:            err.Error(),
+		ObservedGeneration: generation,
+	}
+}
+
+// Resolved returns a condition that indicates that the last request was not
+// rejected for good.
+func Resolved() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTerminalError,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResolved,
+	}
+}
+
+// Blocked returns the TerminalError condition of the supplied managed
+// resource, and whether it applies to its current generation.
+func Blocked(mg resource.Managed) (xpv1.Condition, bool) {
+	c := mg.GetCondition(TypeTerminalError)
+	return c, c.Status == corev1.ConditionTrue && c.ObservedGeneration == mg.GetGeneration()
+}
+
+// retryable returns true if the supplied TerminalError condition may resolve
+// without a change of the spec, i.e. once quota is freed elsewhere.
+func retryable(c xpv1.Condition) bool {
+	return c.Reason == clients.ReasonQuotaExceeded
+}
+
+// NewConnector returns a connector whose clients do not call Create or Update
+// of the supplied connector's clients again once an OTC API rejected the
+// current generation of a managed resource for good.
+func NewConnector(c managed.ExternalConnector, r event.Recorder) managed.ExternalConnector {
+	return &connector{next: c, record: r}
+}
+
+type connector struct {
+	next   managed.ExternalConnector
+	record event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.next.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connector: c, next: ec}, nil
+}
+
+type external struct {
+	*connector
+	next managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return e.next.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if c, ok := Blocked(mg); ok && !retryable(c) {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(c.Message), errBlocked)
+	}
+	cr, err := e.next.Create(ctx, mg)
+	e.classify(mg, err)
+	return cr, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if c, ok := Blocked(mg); ok && !retryable(c) {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(c.Message), errBlocked)
+	}
+	u, err := e.next.Update(ctx, mg)
+	e.classify(mg, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return e.next.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
+
+// classify sets the TerminalError condition of the supplied managed resource
+// according to the supplied error of a Create or Update call.
+func (e *external) classify(mg resource.Managed, err error) {
+	if oe, ok := clients.ParseError(err); ok && oe.Terminal() {
+		mg.SetConditions(Failed(oe, mg.GetGeneration()))
+		e.record.Event(mg, event.Warning(event.Reason(oe.Reason()), oe))
+		return
+	}
+	if mg.GetCondition(TypeTerminalError).Status == corev1.ConditionTrue {
+		mg.SetConditions(Resolved())
+	}
+}
+
+// NewReconciler returns a reconciler that requeues managed resources of the
+// supplied kind whose current generation was rejected for good after the
+// supplied poll interval instead of right away. They are still observed, so
+// drift is detected, and they are reconciled right away once their spec
+// changes.
+func NewReconciler(kube client.Client, of resource.ManagedKind, r reconcile.Reconciler, interval time.Duration) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		res, err := r.Reconcile(ctx, req)
+		if err != nil || res.RequeueAfter > 0 {
+			return res, err
+		}
+		if blocked(ctx, kube, of, req) {
+			return reconcile.Result{RequeueAfter: interval}, nil
+		}
+		return res, nil
+	})
+}
+
+// blocked returns true if the requested managed resource exists and its
+// current generation was rejected for good.
+func blocked(ctx context.Context, kube client.Client, of resource.ManagedKind, req reconcile.Request) bool {
+	obj, err := kube.Scheme().New(schema.GroupVersionKind(of))
+	if err != nil {
+		return false
+	}
+	mg, ok := obj.(resource.Managed)
+	if !ok || kube.Get(ctx, req.NamespacedName, mg) != nil || meta.WasDeleted(mg) {
+		return false
+	}
+	_, ok = Blocked(mg)
+	return ok
+}
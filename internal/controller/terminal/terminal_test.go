@@ -0,0 +1,228 @@
+package terminal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vpcv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/vpc/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+)
+
+// recorder records the reasons of the events it receives.
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func managedWith(generation int64, c ...xpv1.Condition) *fake.Managed {
+	mg := &fake.Managed{}
+	mg.SetGeneration(generation)
+	mg.SetConditions(c...)
+	return mg
+}
+
+func TestCreate(t *testing.T) {
+	errInvalid := golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+		Actual: http.StatusBadRequest,
+		Body:   []byte(`{"code":"VPC.0101","message":"Invalid CIDR"}`),
+	}}
+	invalid, _ := clients.ParseError(errInvalid)
+	quota, _ := clients.ParseError(golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+		Actual: http.StatusBadRequest,
+		Body:   []byte(`{"error_code":"VPC.0115","error_msg":"Quota exceeded for resources: vpc"}`),
+	}})
+
+	type args struct {
+		mg  *fake.Managed
+		err error
+	}
+
+	type want struct {
+		calls   int
+		err     bool
+		status  corev1.ConditionStatus
+		reason  xpv1.ConditionReason
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Terminal": {
+			reason: "Should set the TerminalError condition and record an event for terminal errors",
+			args: args{
+				mg:  managedWith(1),
+				err: errors.Wrap(errInvalid, "cannot create VPC"),
+			},
+			want: want{
+				calls:   1,
+				err:     true,
+				status:  corev1.ConditionTrue,
+				reason:  clients.ReasonInvalidRequest,
+				reasons: []event.Reason{event.Reason(clients.ReasonInvalidRequest)},
+			},
+		},
+		"Transient": {
+			reason: "Should not set the TerminalError condition for other errors",
+			args: args{
+				mg:  managedWith(1),
+				err: errors.New("connection reset"),
+			},
+			want: want{
+				calls:  1,
+				err:    true,
+				status: corev1.ConditionUnknown,
+			},
+		},
+		"Blocked": {
+			reason: "Should not call Create again for the generation that was rejected",
+			args: args{
+				mg: managedWith(1, Failed(invalid, 1)),
+			},
+			want: want{
+				err:    true,
+				status: corev1.ConditionTrue,
+				reason: clients.ReasonInvalidRequest,
+			},
+		},
+		"QuotaFreed": {
+			reason: "Should call Create again for a generation that exhausted a quota, since it may have been freed",
+			args: args{
+				mg: managedWith(1, Failed(quota, 1)),
+			},
+			want: want{
+				calls:  1,
+				status: corev1.ConditionFalse,
+				reason: ReasonResolved,
+			},
+		},
+		"SpecChanged": {
+			reason: "Should call Create again and resolve the condition once the spec changed",
+			args: args{
+				mg: managedWith(2, Failed(invalid, 1)),
+			},
+			want: want{
+				calls:  1,
+				status: corev1.ConditionFalse,
+				reason: ReasonResolved,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			rec := &recorder{}
+			c := NewConnector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						calls++
+						return managed.ExternalCreation{}, tc.args.err
+					},
+				}, nil
+			}), rec)
+
+			ec, err := c.Connect(context.Background(), tc.args.mg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ec.Create(context.Background(), tc.args.mg)
+
+			cond := tc.args.mg.GetCondition(TypeTerminalError)
+			got := want{calls: calls, err: err != nil, status: cond.Status, reason: cond.Reason, reasons: rec.reasons}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewReconciler(t *testing.T) {
+	quota, _ := clients.ParseError(golangsdk.ErrDefault400{ErrUnexpectedResponseCode: golangsdk.ErrUnexpectedResponseCode{
+		Actual: http.StatusBadRequest,
+		Body:   []byte(`{"error_code":"VPC.0115","error_msg":"Quota exceeded for resources: vpc"}`),
+	}})
+
+	s := runtime.NewScheme()
+	if err := vpcv1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		result reconcile.Result
+		c      []xpv1.Condition
+		want   reconcile.Result
+	}{
+		"Blocked": {
+			reason: "Should poll a managed resource that exhausted a quota again after the poll interval",
+			result: reconcile.Result{Requeue: true},
+			c:      []xpv1.Condition{Failed(quota, 1)},
+			want:   reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"Resolved": {
+			reason: "Should requeue managed resources that are not blocked as requested",
+			result: reconcile.Result{Requeue: true},
+			c:      []xpv1.Condition{Resolved()},
+			want:   reconcile.Result{Requeue: true},
+		},
+		"RequeueAfter": {
+			reason: "Should keep the requeue interval requested by the managed reconciler",
+			result: reconcile.Result{RequeueAfter: time.Second},
+			c:      []xpv1.Condition{Failed(quota, 1)},
+			want:   reconcile.Result{RequeueAfter: time.Second},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					mg := obj.(*vpcv1alpha1.VPC)
+					mg.SetGeneration(1)
+					mg.SetConditions(tc.c...)
+					return nil
+				}),
+				MockScheme: test.NewMockSchemeFn(s),
+			}
+			next := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return tc.result, nil
+			})
+
+			r := NewReconciler(kube, resource.ManagedKind(vpcv1alpha1.VPCGroupVersionKind), next, time.Minute)
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "vpc"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package usage tracks which ProviderConfig or ClusterProviderConfig a
+// managed resource uses.
+package usage
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+)
+
+const (
+	errGetUsage    = "cannot get provider config usage"
+	errDeleteUsage = "cannot delete provider config usage"
+)
+
+// A Tracker tracks the usage of the provider config a managed resource
+// references. Depending on the kind of the reference it creates either a
+// ProviderConfigUsage or a ClusterProviderConfigUsage.
+type Tracker struct {
+	kube       client.Client
+	namespaced *resource.ProviderConfigUsageTracker
+	cluster    *resource.ProviderConfigUsageTracker
+}
+
+// NewTracker returns a Tracker that tracks usages with the supplied client.
+func NewTracker(kube client.Client) *Tracker {
+	return &Tracker{
+		kube:       kube,
+		namespaced: resource.NewProviderConfigUsageTracker(kube, &v1alpha1.ProviderConfigUsage{}),
+		cluster:    resource.NewProviderConfigUsageTracker(kube, &v1alpha1.ClusterProviderConfigUsage{}),
+	}
+}
+
+// Track that the supplied managed resource uses the provider config it
+// references. A usage of the other kind, left over from a previous reference,
+// is removed once the current usage is tracked.
+func (t *Tracker) Track(ctx context.Context, mg resource.ModernManaged) error {
+	if ref := mg.GetProviderConfigReference(); ref != nil && ref.Kind == v1alpha1.ClusterProviderConfigKind {
+		if err := t.cluster.Track(ctx, mg); err != nil {
+			return err
+		}
+		return t.remove(ctx, mg, &v1alpha1.ProviderConfigUsage{})
+	}
+
+	if err := t.namespaced.Track(ctx, mg); err != nil {
+		return err
+	}
+	return t.remove(ctx, mg, &v1alpha1.ClusterProviderConfigUsage{})
+}
+
+// remove deletes the supplied kind of usage of the supplied managed resource,
+// if it exists.
+func (t *Tracker) remove(ctx context.Context, mg resource.Managed, pcu client.Object) error {
+	// Usages are named after the UID of the managed resource. The namespace is
+	// ignored for the cluster scoped ClusterProviderConfigUsage.
+	key := types.NamespacedName{Namespace: mg.GetNamespace(), Name: string(mg.GetUID())}
+	if err := t.kube.Get(ctx, key, pcu); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetUsage)
+	}
+
+	if !metav1.IsControlledBy(pcu, mg) {
+		return nil
+	}
+
+	return errors.Wrap(resource.IgnoreNotFound(t.kube.Delete(ctx, pcu)), errDeleteUsage)
+}
+
+// A Finalizer removes the ClusterProviderConfigUsage of a managed resource
+// before it removes the managed resource's finalizer. Unlike a
+// ProviderConfigUsage, the cluster scoped usage is not garbage collected
+// together with its namespaced managed resource.
+type Finalizer struct {
+	*resource.APIFinalizer
+
+	kube client.Client
+}
+
+// NewFinalizer returns a Finalizer that manages the default managed resource
+// finalizer.
+func NewFinalizer(kube client.Client) *Finalizer {
+	return &Finalizer{
+		APIFinalizer: resource.NewAPIFinalizer(kube, managed.FinalizerName),
+		kube:         kube,
+	}
+}
+
+// RemoveFinalizer deletes the ClusterProviderConfigUsage of the supplied
+// managed resource and removes its finalizer.
+func (f *Finalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	// The usage is named like the one created by the Tracker. Its namespace is
+	// ignored by the API server.
+	pcu := &v1alpha1.ClusterProviderConfigUsage{}
+	pcu.SetNamespace(obj.GetNamespace())
+	pcu.SetName(string(obj.GetUID()))
+	if err := f.kube.Delete(ctx, pcu); resource.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, errDeleteUsage)
+	}
+
+	return f.APIFinalizer.RemoveFinalizer(ctx, obj)
+}
@@ -0,0 +1,329 @@
+package usage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/providerconfig"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+
+	"github.com/peertechde/provider-opentelekomcloud/apis"
+	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/apis/vpc/v1alpha1"
+)
+
+const (
+	namespace = "default"
+	uid       = types.UID("9a3c8c35-1b6e-4c1f-8a4e-6f1f2d1d5c0b")
+)
+
+// newClient returns a fake API server that knows the scope of every kind
+// involved in usage tracking.
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	m := kmeta.NewDefaultRESTMapper(nil)
+	for gvk, scope := range map[schema.GroupVersionKind]kmeta.RESTScope{
+		v1alpha1.VPCGroupVersionKind:                            kmeta.RESTScopeNamespace,
+		apisv1alpha1.ProviderConfigGroupVersionKind:             kmeta.RESTScopeNamespace,
+		apisv1alpha1.ProviderConfigUsageGroupVersionKind:        kmeta.RESTScopeNamespace,
+		apisv1alpha1.ClusterProviderConfigGroupVersionKind:      kmeta.RESTScopeRoot,
+		apisv1alpha1.ClusterProviderConfigUsageGroupVersionKind: kmeta.RESTScopeRoot,
+	} {
+		m.Add(gvk, scope)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithRESTMapper(m).
+		WithObjects(objs...).
+		WithStatusSubresource(&apisv1alpha1.ProviderConfig{}, &apisv1alpha1.ClusterProviderConfig{}).
+		Build()
+}
+
+// newAPIServer returns a client of an envtest API server that serves the CRDs
+// of the provider, so that the scope of every kind is the one the CRDs
+// declare. It skips the test unless KUBEBUILDER_ASSETS points to the API
+// server and etcd binaries, e.g. as installed by setup-envtest.
+func newAPIServer(t *testing.T) client.Client {
+	t.Helper()
+
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "package", "crds")},
+		ErrorIfCRDPathMissing: true,
+		Scheme:                s,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kube
+}
+
+func managedVPC(kind string) *v1alpha1.VPC {
+	mg := &v1alpha1.VPC{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.VPCGroupVersionKind.GroupVersion().String(),
+			Kind:       v1alpha1.VPCKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "vpc", UID: uid},
+	}
+	mg.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: kind, Name: "example"})
+	return mg
+}
+
+// usages returns the kinds of the usages that exist for the managed resource.
+func usages(ctx context.Context, t *testing.T, kube client.Client) []string {
+	t.Helper()
+
+	var kinds []string
+	for kind, pcu := range map[string]client.Object{
+		apisv1alpha1.ProviderConfigUsageKind:        &apisv1alpha1.ProviderConfigUsage{},
+		apisv1alpha1.ClusterProviderConfigUsageKind: &apisv1alpha1.ClusterProviderConfigUsage{},
+	} {
+		err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: string(uid)}, pcu)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+func TestTrack(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		refs   []string
+		want   []string
+	}{
+		"ProviderConfig": {
+			reason: "Should create a ProviderConfigUsage for a ProviderConfig reference",
+			refs:   []string{apisv1alpha1.ProviderConfigKind},
+			want:   []string{apisv1alpha1.ProviderConfigUsageKind},
+		},
+		"ClusterProviderConfig": {
+			reason: "Should create a ClusterProviderConfigUsage for a ClusterProviderConfig reference",
+			refs:   []string{apisv1alpha1.ClusterProviderConfigKind},
+			want:   []string{apisv1alpha1.ClusterProviderConfigUsageKind},
+		},
+		"SwitchToClusterProviderConfig": {
+			reason: "Should remove the ProviderConfigUsage once the reference changes to a ClusterProviderConfig",
+			refs:   []string{apisv1alpha1.ProviderConfigKind, apisv1alpha1.ClusterProviderConfigKind},
+			want:   []string{apisv1alpha1.ClusterProviderConfigUsageKind},
+		},
+		"SwitchToProviderConfig": {
+			reason: "Should remove the ClusterProviderConfigUsage once the reference changes to a ProviderConfig",
+			refs:   []string{apisv1alpha1.ClusterProviderConfigKind, apisv1alpha1.ProviderConfigKind},
+			want:   []string{apisv1alpha1.ProviderConfigUsageKind},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			kube := newClient(t)
+			tracker := NewTracker(kube)
+
+			for _, kind := range tc.refs {
+				if err := tracker.Track(ctx, managedVPC(kind)); err != nil {
+					t.Fatalf("\n%s\ntracker.Track(...): -want nil, +got error %v\n", tc.reason, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, usages(ctx, t, kube)); diff != "" {
+				t.Errorf("\n%s\nusages: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTrackAPIServer(t *testing.T) {
+	kube := newAPIServer(t)
+
+	cases := map[string]struct {
+		reason string
+		refs   []string
+		want   []string
+	}{
+		"ProviderConfig": {
+			reason: "Should create a namespaced ProviderConfigUsage next to the managed resource",
+			refs:   []string{apisv1alpha1.ProviderConfigKind},
+			want:   []string{apisv1alpha1.ProviderConfigUsageKind},
+		},
+		"ClusterProviderConfig": {
+			reason: "Should create a cluster scoped ClusterProviderConfigUsage for a namespaced managed resource",
+			refs:   []string{apisv1alpha1.ClusterProviderConfigKind},
+			want:   []string{apisv1alpha1.ClusterProviderConfigUsageKind},
+		},
+		"SwitchToClusterProviderConfig": {
+			reason: "Should remove the ProviderConfigUsage once the reference changes to a ClusterProviderConfig",
+			refs:   []string{apisv1alpha1.ProviderConfigKind, apisv1alpha1.ClusterProviderConfigKind},
+			want:   []string{apisv1alpha1.ClusterProviderConfigUsageKind},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			t.Cleanup(func() {
+				for _, pcu := range []client.Object{&apisv1alpha1.ProviderConfigUsage{}, &apisv1alpha1.ClusterProviderConfigUsage{}} {
+					pcu.SetNamespace(namespace)
+					pcu.SetName(string(uid))
+					if err := kube.Delete(ctx, pcu); resource.IgnoreNotFound(err) != nil {
+						t.Error(err)
+					}
+				}
+			})
+			tracker := NewTracker(kube)
+
+			for _, kind := range tc.refs {
+				if err := tracker.Track(ctx, managedVPC(kind)); err != nil {
+					t.Fatalf("\n%s\ntracker.Track(...): -want nil, +got error %v\n", tc.reason, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, usages(ctx, t, kube)); diff != "" {
+				t.Errorf("\n%s\nusages: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteInUseProviderConfig(t *testing.T) {
+	deleted := metav1.NewTime(time.Now())
+	meta := func(ns string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:         ns,
+			Name:              "example",
+			DeletionTimestamp: &deleted,
+			Finalizers:        []string{"in-use.crossplane.io"},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		config resource.ProviderConfig
+		kinds  resource.ProviderConfigKinds
+	}{
+		"ProviderConfig": {
+			reason: "Should block deletion of a ProviderConfig until its managed resource is gone",
+			config: &apisv1alpha1.ProviderConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: apisv1alpha1.SchemeGroupVersion.String(),
+					Kind:       apisv1alpha1.ProviderConfigKind,
+				},
+				ObjectMeta: meta(namespace),
+			},
+			kinds: resource.ProviderConfigKinds{
+				Config:    apisv1alpha1.ProviderConfigGroupVersionKind,
+				Usage:     apisv1alpha1.ProviderConfigUsageGroupVersionKind,
+				UsageList: apisv1alpha1.ProviderConfigUsageListGroupVersionKind,
+			},
+		},
+		"ClusterProviderConfig": {
+			reason: "Should block deletion of a ClusterProviderConfig until its managed resource is gone",
+			config: &apisv1alpha1.ClusterProviderConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: apisv1alpha1.SchemeGroupVersion.String(),
+					Kind:       apisv1alpha1.ClusterProviderConfigKind,
+				},
+				ObjectMeta: meta(""),
+			},
+			kinds: resource.ProviderConfigKinds{
+				Config:    apisv1alpha1.ClusterProviderConfigGroupVersionKind,
+				Usage:     apisv1alpha1.ClusterProviderConfigUsageGroupVersionKind,
+				UsageList: apisv1alpha1.ClusterProviderConfigUsageListGroupVersionKind,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			mg := managedVPC(tc.config.GetObjectKind().GroupVersionKind().Kind)
+			kube := newClient(t, tc.config, mg)
+
+			if err := NewTracker(kube).Track(ctx, mg); err != nil {
+				t.Fatalf("\n%s\ntracker.Track(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			r := providerconfig.NewReconciler(&xpfake.Manager{Client: kube, Scheme: kube.Scheme()}, tc.kinds)
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.config)}
+
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			pc := tc.config.DeepCopyObject().(resource.ProviderConfig)
+			if err := kube.Get(ctx, req.NamespacedName, pc); err != nil {
+				t.Fatalf("\n%s\nkube.Get(...): -want in use provider config, +got error %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(int64(1), pc.GetUsers()); diff != "" {
+				t.Errorf("\n%s\npc.GetUsers(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+
+			// Deleting the managed resource removes its usages. The cluster
+			// scoped usage is removed by the finalizer, the namespaced usage
+			// would be garbage collected by the API server.
+			if err := NewFinalizer(kube).RemoveFinalizer(ctx, mg); err != nil {
+				t.Fatalf("\n%s\nf.RemoveFinalizer(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+			if err := kube.DeleteAllOf(ctx, &apisv1alpha1.ProviderConfigUsage{}, client.InNamespace(namespace)); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): -want nil, +got error %v\n", tc.reason, err)
+			}
+
+			err := kube.Get(ctx, req.NamespacedName, pc)
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("\n%s\nkube.Get(...): -want not found, +got %v\n", tc.reason, err)
+			}
+		})
+	}
+}
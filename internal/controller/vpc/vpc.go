@@ -2,10 +2,11 @@ package vpc
 
 import (
 	"context"
-	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -14,7 +15,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/common/tags"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/vpcs"
+	vpcsv3 "github.com/opentelekomcloud/gophertelekomcloud/openstack/vpc/v3/vpcs"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,7 +27,20 @@ import (
 	apisv1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/v1alpha1"
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/vpc/v1alpha1"
 	clients "github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/adopt"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/audit"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/dependency"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/drift"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/enterprise"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/importer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/options"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/plan"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/tagging"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/terminal"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/usage"
 	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
+	"github.com/peertechde/provider-opentelekomcloud/internal/tracing"
 )
 
 const (
@@ -36,10 +53,20 @@ const (
 	errCreate       = "cannot create VPC"
 	errUpdate       = "cannot update VPC"
 	errDelete       = "cannot delete VPC"
+	errAdopt        = "cannot look up existing VPCs"
+	errImport       = "cannot import VPC"
+	errTag          = "cannot tag VPC"
+	errObserveTags  = "cannot observe VPC tags"
+	errUpdateTags   = "cannot update VPC tags"
+	errAddCIDR      = "cannot add secondary CIDR %s to VPC"
+	errRemoveCIDR   = "cannot remove secondary CIDR %s from VPC"
 )
 
+// tagResourceType is the resource type of VPCs in the tag API.
+const tagResourceType = "vpcs"
+
 // SetupGated adds a controller that reconciles VPC managed resources with safe-start support.
-func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+func SetupGated(mgr ctrl.Manager, o options.Options) error {
 	o.Gate.Register(func() {
 		if err := Setup(mgr, o); err != nil {
 			panic(errors.Wrap(err, "cannot setup VPC controller"))
@@ -49,25 +76,21 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 }
 
 // Setup adds a controller that reconciles VPC managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.VPCGroupKind)
-
-	// Initialize the client caching
-	clientCache := clients.NewCache(mgr.GetClient())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	opts := []managed.ReconcilerOption{
-		managed.WithExternalConnector(&connector{
-			kube: mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(
-				mgr.GetClient(),
-				&apisv1alpha1.ProviderConfigUsage{},
-			),
-			clientCache: clientCache,
-		}),
+		managed.WithExternalConnector(tracing.NewConnector(name, plan.NewConnector(drift.NewConnector(terminal.NewConnector(audit.NewConnector(&connector{
+			kube:        mgr.GetClient(),
+			usage:       usage.NewTracker(mgr.GetClient()),
+			clientCache: o.Sessions,
+		}, recorder), recorder), recorder), o.Plan, recorder))),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+		managed.WithFinalizer(usage.NewFinalizer(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -98,19 +121,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.VPCGroupVersionKind), opts...)
+	kind := resource.ManagedKind(v1alpha1.VPCGroupVersionKind)
+	r := terminal.NewReconciler(mgr.GetClient(), kind, managed.NewReconciler(mgr, kind, opts...), o.PollInterval)
+	r = dependency.NewReconciler(mgr.GetClient(), kind, r, o.PollInterval)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.VPC{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, tracing.NewReconciler(name, r), o.GlobalRateLimiter))
 }
 
 type connector struct {
 	kube        client.Client
-	usage       *resource.ProviderConfigUsageTracker
+	usage       *usage.Tracker
 	clientCache *clients.Cache
 }
 
@@ -142,14 +167,14 @@ func (c *connector) Connect(
 			return nil, errors.Wrap(err, errGetPC)
 		}
 		spec = pc.Spec
-		cacheKey = fmt.Sprintf("ProviderConfig/%s/%s", pc.Namespace, pc.Name)
+		cacheKey = clients.ProviderConfigKey(pc.Namespace, pc.Name)
 	case "ClusterProviderConfig":
 		cpc := &apisv1alpha1.ClusterProviderConfig{}
 		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, cpc); err != nil {
 			return nil, errors.Wrap(err, errGetCPC)
 		}
 		spec = cpc.Spec
-		cacheKey = fmt.Sprintf("ClusterProviderConfig/%s", cpc.Name)
+		cacheKey = clients.ClusterProviderConfigKey(cpc.Name)
 	default:
 		return nil, errors.Errorf("unsupported provider config kind: %s", ref.Kind)
 	}
@@ -166,12 +191,96 @@ func (c *connector) Connect(
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: networkClient}, nil
+	// VPC tags are only available in the Network V2 API
+	tagClient, err := providerClient.NewNetworkV2Client()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	// VPC secondary CIDRs are only available in the VPC V3 API
+	v3Client, err := providerClient.NewVPCV3Client()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		client:             networkClient,
+		tagClient:          tagClient,
+		v3Client:           v3Client,
+		deletionProtection: pointer.Deref(spec.DeletionProtection, false),
+		defaultTags:        spec.DefaultTags,
+		namePrefix:         pointer.Deref(spec.NamePrefix, ""),
+		enterpriseProject:  pointer.Deref(spec.EnterpriseProjectID, ""),
+		migrator:           enterprise.NewMigrator(providerClient.NewEPSClient, providerClient.Region),
+	}, nil
 }
 
 // external implements managed.ExternalClient for VPC resources.
 type external struct {
-	client *golangsdk.ServiceClient
+	client    *golangsdk.ServiceClient
+	tagClient *golangsdk.ServiceClient
+	v3Client  *golangsdk.ServiceClient
+
+	// deletionProtection is the deletion protection default of the
+	// ProviderConfig.
+	deletionProtection bool
+
+	// defaultTags are the default tags of the ProviderConfig.
+	defaultTags map[string]string
+
+	// namePrefix is the name prefix of the ProviderConfig.
+	namePrefix string
+
+	// enterpriseProject is the enterprise project default of the
+	// ProviderConfig.
+	enterpriseProject string
+
+	// migrator migrates VPCs between enterprise projects.
+	migrator *enterprise.Migrator
+}
+
+// network is a VPC of the VPC v3 API including its enterprise project, which
+// the vpcs package does not expose.
+type network struct {
+	vpcsv3.Vpc
+	EnterpriseProjectID string `json:"enterprise_project_id"`
+}
+
+// createOpts are the options to create a VPC in an enterprise project.
+type createOpts struct {
+	vpcs.CreateOpts
+	EnterpriseProjectID string `json:"enterprise_project_id,omitempty"`
+}
+
+func (opts createOpts) ToVpcCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "vpc")
+}
+
+// desiredTags returns the desired tags of the supplied VPC, including the
+// default tags of the ProviderConfig.
+func (e *external) desiredTags(spec *v1alpha1.VPCParameters) map[string]string {
+	return tagging.Merge(e.defaultTags, spec.Tags)
+}
+
+// name returns the name of the supplied VPC, including the name prefix
+// of the ProviderConfig.
+func (e *external) name(spec *v1alpha1.VPCParameters) string {
+	return e.namePrefix + spec.Name
+}
+
+// enterpriseProjectID returns the enterprise project of the supplied VPC,
+// defaulting to the enterprise project of the ProviderConfig.
+func (e *external) enterpriseProjectID(spec *v1alpha1.VPCParameters) string {
+	return enterprise.ProjectID(spec.EnterpriseProjectID, e.enterpriseProject)
+}
+
+// get returns the VPC with the supplied ID.
+func (e *external) get(id string) (*network, error) {
+	var r golangsdk.Result
+	_, r.Err = e.v3Client.Get(e.v3Client.ServiceURL("vpcs", id), &r.Body, openstack.StdRequestOpts())
+	vpc := new(network)
+	err := r.ExtractIntoStructPtr(vpc, "vpc")
+	return vpc, err
 }
 
 func (e *external) Observe(
@@ -184,11 +293,22 @@ func (e *external) Observe(
 	}
 
 	externalName := meta.GetExternalName(cr)
+	imported := false
+	if externalName == "" && cr.Spec.ImportSelector != nil {
+		id, err := e.resolveImport(cr.Spec.ImportSelector)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errImport)
+		}
+		// Reporting the managed resource as late initialized persists the
+		// pinned external name.
+		meta.SetExternalName(cr, id)
+		externalName, imported = id, true
+	}
 	if externalName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	vpc, err := vpcs.Get(e.client, externalName).Extract()
+	vpc, err := e.get(externalName)
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
@@ -197,45 +317,80 @@ func (e *external) Observe(
 		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
 	}
 
+	var managedTags map[string]string
+	if tagging.Needed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags) {
+		ts, err := e.getTags(vpc.Id)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveTags)
+		}
+		managedTags = tagging.Managed(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags, ts)
+	}
+
+	managedCIDRs := secondaryCIDRs(cr.Spec.ForProvider.SecondaryCIDRs, cr.Status.AtProvider.SecondaryCIDRs, vpc.SecondaryCidrs)
+
 	// Update observed state
 	cr.Status.AtProvider = v1alpha1.VPCObservation{
-		ID:     vpc.ID,
-		Status: vpc.Status,
-		CIDR:   vpc.CIDR,
+		ID:                  vpc.Id,
+		Status:              vpc.Status,
+		CIDR:                vpc.Cidr,
+		CIDRs:               append([]string{vpc.Cidr}, vpc.SecondaryCidrs...),
+		SecondaryCIDRs:      managedCIDRs,
+		EnterpriseProjectID: vpc.EnterpriseProjectID,
+		Tags:                managedTags,
 	}
 
-	// Set conditions based on status
+	// Set conditions based on the status of the VPC v3 API
 	switch vpc.Status {
-	case "ACTIVE", "OK":
+	case "ACTIVE":
 		cr.SetConditions(xpv1.Available())
-	case "CREATING", "PENDING_UPDATE":
+	case "PENDING":
 		cr.SetConditions(xpv1.Creating())
-	case "PENDING_DELETE":
-		cr.SetConditions(xpv1.Deleting())
 	default:
 		cr.SetConditions(xpv1.Unavailable())
 	}
 
-	needsUpdate := e.detectDrift(&cr.Spec.ForProvider, vpc)
+	diff := e.detectDrift(&cr.Spec.ForProvider, vpc, managedCIDRs, managedTags)
+	cr.SetConditions(drift.Condition(diff, cr.GetGeneration()))
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: !needsUpdate,
+		ResourceExists:          true,
+		ResourceUpToDate:        diff.Empty(),
+		ResourceLateInitialized: imported,
 	}, nil
 }
 
-func (e *external) detectDrift(spec *v1alpha1.VPCParameters, actual *vpcs.Vpc) bool {
-	if actual.Name != spec.Name {
-		return true
-	}
-	if actual.CIDR != spec.CIDR {
-		return true
+// resolveImport returns the ID of the only VPC that matches the supplied
+// import selector.
+func (e *external) resolveImport(s *v1alpha1.VPCImportSelector) (string, error) {
+	vs, err := vpcs.List(e.client, vpcs.ListOpts{
+		Name: pointer.Deref(s.Name, ""),
+		CIDR: pointer.Deref(s.CIDR, ""),
+	})
+	if err != nil {
+		return "", err
 	}
-	if pointer.Deref(spec.Description, actual.Description) != actual.Description {
-		return true
+
+	ids := make([]string, len(vs))
+	for i, v := range vs {
+		ids[i] = v.ID
 	}
+	return importer.Select(ids, s.Tags, e.getTags)
+}
 
-	return false
+func (e *external) detectDrift(
+	spec *v1alpha1.VPCParameters,
+	actual *network,
+	managedCIDRs []string,
+	managedTags map[string]string,
+) drift.Diff {
+	var d drift.Diff
+	d.Compare("spec.forProvider.name", e.name(spec), actual.Name)
+	d.CompareImmutable("spec.forProvider.cidr", spec.CIDR, actual.Cidr)
+	d.Compare("spec.forProvider.secondaryCidrs", cidrSet(spec.SecondaryCIDRs), cidrSet(managedCIDRs))
+	d.Compare("spec.forProvider.description", pointer.Deref(spec.Description, actual.Description), actual.Description)
+	enterprise.Compare(&d, "spec.forProvider.enterpriseProjectId", e.enterpriseProjectID(spec), actual.EnterpriseProjectID)
+	tagging.Compare(&d, "spec.forProvider.tags", e.desiredTags(spec), managedTags)
+	return d
 }
 
 func (e *external) Create(
@@ -249,9 +404,21 @@ func (e *external) Create(
 
 	cr.SetConditions(xpv1.Creating())
 
-	createOpts := vpcs.CreateOpts{
-		Name: cr.Spec.ForProvider.Name,
-		CIDR: cr.Spec.ForProvider.CIDR,
+	id, err := e.adopt(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if id != "" {
+		meta.SetExternalName(cr, id)
+		return managed.ExternalCreation{}, nil
+	}
+
+	createOpts := createOpts{
+		CreateOpts: vpcs.CreateOpts{
+			Name: e.name(&cr.Spec.ForProvider),
+			CIDR: cr.Spec.ForProvider.CIDR,
+		},
+		EnterpriseProjectID: e.enterpriseProjectID(&cr.Spec.ForProvider),
 	}
 
 	if cr.Spec.ForProvider.Description != nil {
@@ -266,9 +433,65 @@ func (e *external) Create(
 	// Set external name to the vpc ID
 	meta.SetExternalName(cr, vpc.ID)
 
+	if err := e.tag(cr, vpc.ID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := e.updateCIDRs(vpc.ID, cr.Spec.ForProvider.SecondaryCIDRs, nil); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{}, nil
 }
 
+// adopt returns the ID of an existing VPC with the name and CIDR of the
+// supplied VPC that carries its UID tag, its desired tags or no tags at all,
+// or an empty string if there is none. VPCs cannot be tagged on creation, so
+// a VPC that was created but not tagged yet is matched by name and CIDR. An
+// adopted VPC is tagged with the UID of the managed resource.
+func (e *external) adopt(cr *v1alpha1.VPC) (string, error) {
+	candidates, err := vpcs.List(e.client, vpcs.ListOpts{
+		Name: e.name(&cr.Spec.ForProvider),
+		CIDR: cr.Spec.ForProvider.CIDR,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errAdopt)
+	}
+
+	cs := make([]adopt.Candidate, len(candidates))
+	for i, c := range candidates {
+		ts, err := e.getTags(c.ID)
+		if err != nil {
+			return "", errors.Wrap(err, errAdopt)
+		}
+		cs[i] = adopt.Candidate{ID: c.ID, Tags: ts}
+	}
+
+	id, err := adopt.SelectUntagged(cr, e.desiredTags(&cr.Spec.ForProvider), cs)
+	if err != nil || id == "" {
+		return "", err
+	}
+	for _, c := range cs {
+		if c.ID == id && adopt.Tagged(cr, c.Tags) {
+			return id, nil
+		}
+	}
+	return id, e.tag(cr, id)
+}
+
+// getTags returns the tags of the VPC with the supplied ID.
+func (e *external) getTags(id string) ([]tags.ResourceTag, error) {
+	return tags.Get(e.tagClient, tagResourceType, id).Extract()
+}
+
+// tag stamps the UID of the supplied managed resource and its desired tags on
+// the VPC with the supplied ID. VPCs cannot be tagged on creation, so a VPC
+// whose UID tag is missing is found by name and CIDR.
+func (e *external) tag(cr *v1alpha1.VPC, id string) error {
+	t := append(adopt.Tag(cr), tagging.ResourceTags(e.desiredTags(&cr.Spec.ForProvider))...)
+	return errors.Wrap(tagging.Apply(e.tagClient, tagResourceType, id, t, nil), errTag)
+}
+
 func (e *external) Update(
 	ctx context.Context,
 	mg resource.Managed,
@@ -278,14 +501,16 @@ func (e *external) Update(
 		return managed.ExternalUpdate{}, errors.New(errNotVPC)
 	}
 
-	if cr.Spec.ForProvider.CIDR != cr.Status.AtProvider.CIDR {
-		return managed.ExternalUpdate{}, errors.New("cannot update immutable field: CIDR")
+	var immutable drift.Diff
+	immutable.CompareImmutable("spec.forProvider.cidr", cr.Spec.ForProvider.CIDR, cr.Status.AtProvider.CIDR)
+	if err := immutable.Err(); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	externalName := meta.GetExternalName(cr)
 
 	opts := vpcs.UpdateOpts{
-		Name: cr.Spec.ForProvider.Name,
+		Name: e.name(&cr.Spec.ForProvider),
 	}
 
 	if cr.Spec.ForProvider.Description != nil {
@@ -297,9 +522,90 @@ func (e *external) Update(
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
 	}
 
+	ep := e.enterpriseProjectID(&cr.Spec.ForProvider)
+	if enterprise.NeedsMigration(ep, cr.Status.AtProvider.EnterpriseProjectID) {
+		if err := e.migrator.Migrate(enterprise.ResourceTypeVPC, externalName, ep); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	add, remove := cidrChanges(cr.Spec.ForProvider.SecondaryCIDRs, cr.Status.AtProvider.SecondaryCIDRs)
+	if err := e.updateCIDRs(externalName, add, remove); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	upsert, removeTags := tagging.Changes(e.desiredTags(&cr.Spec.ForProvider), cr.Status.AtProvider.Tags)
+	if err := tagging.Apply(e.tagClient, tagResourceType, externalName, upsert, removeTags); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTags)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
+// updateCIDRs removes and then adds the supplied secondary CIDRs of the VPC
+// with the supplied ID. Removing first keeps the VPC within its limit of
+// secondary CIDRs when CIDRs are replaced.
+func (e *external) updateCIDRs(id string, add, remove []string) error {
+	// The VPC v3 API changes a single secondary CIDR per request.
+	for _, c := range remove {
+		opts := vpcsv3.CidrOpts{Vpc: &vpcsv3.AddExtendCidrOption{ExtendCidrs: []string{c}}}
+		if _, err := vpcsv3.RemoveSecondaryCidr(e.v3Client, id, opts); err != nil {
+			return errors.Wrapf(err, errRemoveCIDR, c)
+		}
+	}
+	for _, c := range add {
+		opts := vpcsv3.CidrOpts{Vpc: &vpcsv3.AddExtendCidrOption{ExtendCidrs: []string{c}}}
+		if _, err := vpcsv3.AddSecondaryCidr(e.v3Client, id, opts); err != nil {
+			return errors.Wrapf(err, errAddCIDR, c)
+		}
+	}
+	return nil
+}
+
+// secondaryCIDRs returns the observed secondary CIDRs that are managed by a
+// VPC: the ones it desires and the ones it managed before that the VPC still
+// has. Like tags, other secondary CIDRs of the VPC are never touched.
+func secondaryCIDRs(desired, before, observed []string) []string {
+	managed := make(map[string]bool, len(desired)+len(before))
+	for _, c := range desired {
+		managed[c] = true
+	}
+	for _, c := range before {
+		managed[c] = true
+	}
+	var cs []string
+	for _, c := range observed {
+		if managed[c] {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// cidrChanges returns the secondary CIDRs to add and to remove to turn the
+// managed secondary CIDRs into the desired ones.
+func cidrChanges(desired, managed []string) (add, remove []string) {
+	for _, c := range desired {
+		if !slices.Contains(managed, c) {
+			add = append(add, c)
+		}
+	}
+	for _, c := range managed {
+		if !slices.Contains(desired, c) {
+			remove = append(remove, c)
+		}
+	}
+	return add, remove
+}
+
+// cidrSet returns a readable, order independent representation of the
+// supplied CIDRs.
+func cidrSet(cs []string) string {
+	s := append([]string(nil), cs...)
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
+
 func (e *external) Delete(
 	ctx context.Context,
 	mg resource.Managed,
@@ -309,6 +615,10 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, errors.New(errNotVPC)
 	}
 
+	if err := protection.Check(cr, e.deletionProtection); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	cr.SetConditions(xpv1.Deleting())
 
 	externalName := meta.GetExternalName(cr)
@@ -316,7 +626,7 @@ func (e *external) Delete(
 		return managed.ExternalDelete{}, nil
 	}
 
-	err := vpcs.Delete(e.client, externalName).ExtractErr()
+	err := dependency.Wait(cr, vpcs.Delete(e.client, externalName).ExtractErr(), "subnets", "NAT gateways")
 	if err != nil {
 		var notFound golangsdk.ErrDefault404
 		if errors.As(err, &notFound) {
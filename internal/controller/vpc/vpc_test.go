@@ -2,20 +2,31 @@ package vpc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/testhelper"
 	fake "github.com/opentelekomcloud/gophertelekomcloud/testhelper/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 
 	v1alpha1 "github.com/peertechde/provider-opentelekomcloud/apis/vpc/v1alpha1"
+	"github.com/peertechde/provider-opentelekomcloud/internal/clients"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/adopt"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/enterprise"
+	"github.com/peertechde/provider-opentelekomcloud/internal/controller/protection"
+	"github.com/peertechde/provider-opentelekomcloud/internal/pointer"
 )
 
 type params func(*v1alpha1.VPC)
@@ -52,8 +63,9 @@ func TestObserve(t *testing.T) {
 	}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		o      managed.ExternalObservation
+		err    error
+		status xpv1.ConditionReason
 	}
 
 	cases := map[string]struct {
@@ -111,7 +123,7 @@ func TestObserve(t *testing.T) {
 								"id": "vpc-id-123",
 								"name": "test-vpc",
 								"cidr": "192.168.0.0/16",
-								"status": "OK"
+								"status": "ACTIVE"
 							}
 						}
 					`)
@@ -126,6 +138,28 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
+				status: xpv1.ReasonAvailable,
+			},
+		},
+		"Pending": {
+			reason: "Should report a VPC that is still being created as creating",
+			fields: fields{
+				handler: func(w http.ResponseWriter, r *http.Request) {
+					testhelper.TestMethod(t, r, "GET")
+					w.Header().Add("Content-Type", "application/json")
+					fmt.Fprint(w, `{"vpc":{"id":"vpc-id-123","name":"test-vpc","cidr":"192.168.0.0/16","status":"PENDING"}}`)
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  vpc(withExternalName("vpc-id-123"), withSpec("test-vpc", "192.168.0.0/16")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				status: xpv1.ReasonCreating,
 			},
 		},
 		"DriftDetected": {
@@ -143,7 +177,7 @@ func TestObserve(t *testing.T) {
 								"id": "vpc-id-123",
 								"name": "old-name",
 								"cidr": "192.168.0.0/16",
-								"status": "OK"
+								"status": "ACTIVE"
 							}
 						}
 					`)
@@ -158,6 +192,7 @@ func TestObserve(t *testing.T) {
 					ResourceExists:   true,
 					ResourceUpToDate: false, // Drift
 				},
+				status: xpv1.ReasonAvailable,
 			},
 		},
 	}
@@ -179,7 +214,7 @@ func TestObserve(t *testing.T) {
 			sc := fake.ServiceClient()
 			sc.Endpoint = testhelper.Endpoint()
 
-			e := external{client: sc}
+			e := external{client: sc, v3Client: sc}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 
 			if tc.want.err != nil {
@@ -195,6 +230,677 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.status, tc.args.mg.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want ready reason, +got ready reason:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withAnnotation(key, value string) params {
+	return func(v *v1alpha1.VPC) {
+		meta.AddAnnotations(v, map[string]string{key: value})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type fields struct {
+		deletionProtection bool
+	}
+
+	type want struct {
+		deleted   bool
+		err       bool
+		protected bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     *v1alpha1.VPC
+		want   want
+	}{
+		"Unprotected": {
+			reason: "Should delete unprotected VPCs",
+			mg:     vpc(withExternalName("vpc-id-123")),
+			want: want{
+				deleted: true,
+			},
+		},
+		"Annotation": {
+			reason: "Should refuse to delete VPCs protected by the annotation",
+			mg: vpc(
+				withExternalName("vpc-id-123"),
+				withAnnotation(protection.AnnotationDeletionProtection, "true"),
+			),
+			want: want{
+				err:       true,
+				protected: true,
+			},
+		},
+		"ProviderConfigDefault": {
+			reason: "Should refuse to delete VPCs protected by the ProviderConfig default",
+			fields: fields{deletionProtection: true},
+			mg:     vpc(withExternalName("vpc-id-123")),
+			want: want{
+				err:       true,
+				protected: true,
+			},
+		},
+		"Lifted": {
+			reason: "Should delete VPCs whose annotation lifts the ProviderConfig default and clear the earlier refusal",
+			fields: fields{deletionProtection: true},
+			mg: vpc(
+				withExternalName("vpc-id-123"),
+				withAnnotation(protection.AnnotationDeletionProtection, "false"),
+				func(v *v1alpha1.VPC) { v.SetConditions(protection.Refused()) },
+			),
+			want: want{
+				deleted: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			deleted := false
+			testhelper.Mux.HandleFunc("/vpcs/vpc-id-123", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "DELETE")
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+
+			e := external{client: sc, deletionProtection: tc.fields.deletionProtection}
+			_, err := e.Delete(context.Background(), tc.mg)
+
+			got := want{
+				deleted:   deleted,
+				err:       err != nil,
+				protected: tc.mg.GetCondition(protection.TypeDeletionProtected).Status == corev1.ConditionTrue,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withUID(uid string) params {
+	return func(v *v1alpha1.VPC) {
+		v.SetUID(types.UID(uid))
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type fields struct {
+		// vpcs are the existing VPCs and their tags.
+		vpcs map[string]string
+		// tags are the desired tags of the managed resource.
+		tags map[string]string
+	}
+
+	type want struct {
+		externalName string
+		created      bool
+		tagged       []string
+		err          bool
+	}
+
+	untagged := `{"tags":[]}`
+	labelled := `{"tags":[{"key":"team","value":"network"}]}`
+	owned := fmt.Sprintf(`{"tags":[{"key":%q,"value":"uid-1"}]}`, adopt.TagUID)
+	foreign := fmt.Sprintf(`{"tags":[{"key":%q,"value":"uid-2"}]}`, adopt.TagUID)
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"Create": {
+			reason: "Should create and tag a VPC if there is none to adopt",
+			want: want{
+				externalName: "vpc-new",
+				created:      true,
+				tagged:       []string{"vpc-new"},
+			},
+		},
+		"Untagged": {
+			reason: "Should adopt and tag a single VPC with the same name and CIDR that was created but not tagged yet",
+			fields: fields{vpcs: map[string]string{"vpc-a": untagged}},
+			want: want{
+				externalName: "vpc-a",
+				tagged:       []string{"vpc-a"},
+			},
+		},
+		"Mislabelled": {
+			reason: "Should report a single VPC with the same name and CIDR that carries other tags instead of adopting it",
+			fields: fields{
+				vpcs: map[string]string{"vpc-a": labelled},
+				tags: map[string]string{"team": "storage"},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"AdoptDesiredTags": {
+			reason: "Should adopt and tag a single VPC with the same name, CIDR and desired tags",
+			fields: fields{
+				vpcs: map[string]string{"vpc-a": labelled, "vpc-b": untagged},
+				tags: map[string]string{"team": "network"},
+			},
+			want: want{
+				externalName: "vpc-a",
+				tagged:       []string{"vpc-a"},
+			},
+		},
+		"AdoptOwned": {
+			reason: "Should adopt the VPC tagged with the UID of the managed resource",
+			fields: fields{vpcs: map[string]string{"vpc-a": untagged, "vpc-b": owned}},
+			want: want{
+				externalName: "vpc-b",
+			},
+		},
+		"Foreign": {
+			reason: "Should not adopt VPCs of other managed resources",
+			fields: fields{vpcs: map[string]string{"vpc-a": foreign}},
+			want: want{
+				externalName: "vpc-new",
+				created:      true,
+				tagged:       []string{"vpc-new"},
+			},
+		},
+		"Ambiguous": {
+			reason: "Should report several VPCs that could be adopted instead of guessing",
+			fields: fields{
+				vpcs: map[string]string{"vpc-a": labelled, "vpc-b": labelled},
+				tags: map[string]string{"team": "network"},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			got := want{}
+			testhelper.Mux.HandleFunc("/project/vpcs", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					got.created = true
+					fmt.Fprint(w, `{"vpc":{"id":"vpc-new","name":"test-vpc","cidr":"192.168.0.0/16"}}`)
+					return
+				}
+				vpcs := make([]string, 0, len(tc.fields.vpcs))
+				for id := range tc.fields.vpcs {
+					vpcs = append(vpcs, fmt.Sprintf(`{"id":%q,"name":"test-vpc","cidr":"192.168.0.0/16"}`, id))
+				}
+				fmt.Fprintf(w, `{"vpcs":[%s]}`, strings.Join(vpcs, ","))
+			})
+			testhelper.Mux.HandleFunc("/project/vpcs/", func(w http.ResponseWriter, r *http.Request) {
+				id := strings.Split(strings.TrimPrefix(r.URL.Path, "/project/vpcs/"), "/")[0]
+				if r.Method == http.MethodPost {
+					got.tagged = append(got.tagged, id)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, tc.fields.vpcs[id])
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+			sc.ProjectID = "project"
+
+			mg := vpc(withSpec("test-vpc", "192.168.0.0/16"), withUID("uid-1"))
+			mg.Spec.ForProvider.Tags = tc.fields.tags
+			e := external{client: sc, tagClient: sc, v3Client: sc}
+			_, err := e.Create(context.Background(), mg)
+
+			got.externalName = meta.GetExternalName(mg)
+			got.err = err != nil
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func withImportSelector(s *v1alpha1.VPCImportSelector) params {
+	return func(v *v1alpha1.VPC) {
+		v.Spec.ImportSelector = s
+	}
+}
+
+func TestObserveImport(t *testing.T) {
+	type want struct {
+		o            managed.ExternalObservation
+		externalName string
+		err          bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.VPC
+		want   want
+	}{
+		"Import": {
+			reason: "Should pin the external name of the only VPC that matches the import selector",
+			mg: vpc(
+				withSpec("prod", "10.0.0.0/16"),
+				withImportSelector(&v1alpha1.VPCImportSelector{Name: pointer.To("prod")}),
+			),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "vpc-prod",
+			},
+		},
+		"Several": {
+			reason: "Should fail if more than one VPC matches the import selector",
+			mg: vpc(
+				withImportSelector(&v1alpha1.VPCImportSelector{CIDR: pointer.To("10.0.0.0/16")}),
+			),
+			want: want{
+				err: true,
+			},
+		},
+		"NoMatch": {
+			reason: "Should fail if no VPC matches the import selector",
+			mg: vpc(
+				withImportSelector(&v1alpha1.VPCImportSelector{Name: pointer.To("staging")}),
+			),
+			want: want{
+				err: true,
+			},
+		},
+		"Pinned": {
+			reason: "Should not resolve the import selector again once the external name is pinned",
+			mg: vpc(
+				withExternalName("vpc-prod"),
+				withSpec("prod", "10.0.0.0/16"),
+				withImportSelector(&v1alpha1.VPCImportSelector{Name: pointer.To("staging")}),
+			),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				externalName: "vpc-prod",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			testhelper.Mux.HandleFunc("/project/vpcs", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "GET")
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, `{"vpcs":[
+					{"id":"vpc-prod","name":"prod","cidr":"10.0.0.0/16","status":"ACTIVE"},
+					{"id":"vpc-dev","name":"dev","cidr":"10.0.0.0/16","status":"ACTIVE"}
+				]}`)
+			})
+			testhelper.Mux.HandleFunc("/project/vpcs/vpc-prod", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "GET")
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, `{"vpc":{"id":"vpc-prod","name":"prod","cidr":"10.0.0.0/16","status":"ACTIVE"}}`)
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+			sc.ProjectID = "project"
+
+			// The VPC V3 API has the project in its resource base
+			v3 := fake.ServiceClient()
+			v3.ResourceBase = testhelper.Endpoint() + "project/"
+
+			e := external{client: sc, tagClient: sc, v3Client: v3}
+			o, err := e.Observe(context.Background(), tc.mg)
+
+			got := want{o: o, externalName: meta.GetExternalName(tc.mg), err: err != nil}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecondaryCIDRs(t *testing.T) {
+	type args struct {
+		desired  []string
+		before   []string
+		observed []string
+	}
+
+	type want struct {
+		managed []string
+		add     []string
+		remove  []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"InSync": {
+			reason: "Should not touch secondary CIDRs that the managed resource does not manage",
+			args: args{
+				desired:  []string{"10.1.0.0/16"},
+				observed: []string{"10.1.0.0/16", "10.2.0.0/16"},
+			},
+			want: want{
+				managed: []string{"10.1.0.0/16"},
+			},
+		},
+		"Missing": {
+			reason: "Should add desired secondary CIDRs that the VPC does not have",
+			args: args{
+				desired:  []string{"10.1.0.0/16", "10.2.0.0/16"},
+				before:   []string{"10.1.0.0/16"},
+				observed: []string{"10.1.0.0/16"},
+			},
+			want: want{
+				managed: []string{"10.1.0.0/16"},
+				add:     []string{"10.2.0.0/16"},
+			},
+		},
+		"Removed": {
+			reason: "Should remove secondary CIDRs that were managed before but are no longer desired",
+			args: args{
+				before:   []string{"10.1.0.0/16"},
+				observed: []string{"10.1.0.0/16", "10.2.0.0/16"},
+			},
+			want: want{
+				managed: []string{"10.1.0.0/16"},
+				remove:  []string{"10.1.0.0/16"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := secondaryCIDRs(tc.args.desired, tc.args.before, tc.args.observed)
+			add, remove := cidrChanges(tc.args.desired, m)
+
+			got := want{managed: m, add: add, remove: remove}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nsecondaryCIDRs, cidrChanges: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateSecondaryCIDRs(t *testing.T) {
+	type args struct {
+		desired []string
+		managed []string
+		quota   string
+	}
+
+	type want struct {
+		calls []string
+		err   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Several": {
+			reason: "Should remove and then add several secondary CIDRs one request at a time",
+			args: args{
+				desired: []string{"10.1.0.0/16", "10.3.0.0/16", "10.4.0.0/16"},
+				managed: []string{"10.1.0.0/16", "10.2.0.0/16", "10.5.0.0/16"},
+			},
+			want: want{
+				calls: []string{
+					"remove-extend-cidr 10.2.0.0/16",
+					"remove-extend-cidr 10.5.0.0/16",
+					"add-extend-cidr 10.3.0.0/16",
+					"add-extend-cidr 10.4.0.0/16",
+				},
+			},
+		},
+		"QuotaExceeded": {
+			reason: "Should surface the quota error of the secondary CIDR that exceeds it",
+			args: args{
+				desired: []string{"10.3.0.0/16", "10.4.0.0/16"},
+				quota:   "10.4.0.0/16",
+			},
+			want: want{
+				calls: []string{
+					"add-extend-cidr 10.3.0.0/16",
+					"add-extend-cidr 10.4.0.0/16",
+				},
+				err: "cannot add secondary CIDR 10.4.0.0/16 to VPC",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			testhelper.Mux.HandleFunc("/project/vpcs/vpc-id-123", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "PUT")
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, `{"vpc":{"id":"vpc-id-123","name":"test-vpc","cidr":"192.168.0.0/16","status":"ACTIVE"}}`)
+			})
+
+			var calls []string
+			cidrs := func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "PUT")
+				var body struct {
+					VPC struct {
+						ExtendCIDRs []string `json:"extend_cidrs"`
+					} `json:"vpc"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				action := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				calls = append(calls, action+" "+strings.Join(body.VPC.ExtendCIDRs, ","))
+
+				w.Header().Add("Content-Type", "application/json")
+				if slices.Contains(body.VPC.ExtendCIDRs, tc.args.quota) {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error_code":"VPC.0115","error_msg":"Quota exceeded for resources: extend_cidr"}`)
+					return
+				}
+				fmt.Fprint(w, `{"vpc":{"id":"vpc-id-123"}}`)
+			}
+			testhelper.Mux.HandleFunc("/v3/project/vpcs/vpc-id-123/add-extend-cidr", cidrs)
+			testhelper.Mux.HandleFunc("/v3/project/vpcs/vpc-id-123/remove-extend-cidr", cidrs)
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+			sc.ProjectID = "project"
+
+			v3 := fake.ServiceClient()
+			v3.ResourceBase = testhelper.Endpoint() + "v3/project/"
+
+			mg := vpc(withExternalName("vpc-id-123"), withSpec("test-vpc", "192.168.0.0/16"))
+			mg.Spec.ForProvider.SecondaryCIDRs = tc.args.desired
+			mg.Status.AtProvider.CIDR = "192.168.0.0/16"
+			mg.Status.AtProvider.SecondaryCIDRs = tc.args.managed
+
+			e := external{client: sc, tagClient: sc, v3Client: v3}
+			_, err := e.Update(context.Background(), mg)
+
+			got := want{calls: calls}
+			if err != nil {
+				oe, ok := clients.ParseError(err)
+				if !ok || oe.Reason() != clients.ReasonQuotaExceeded {
+					t.Errorf("\n%s\ne.Update(...): -want quota exceeded, +got %v\n", tc.reason, err)
+				}
+				// The SDK error follows the message of the CIDR.
+				got.err, _, _ = strings.Cut(err.Error(), ": ")
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnterpriseProject(t *testing.T) {
+	type args struct {
+		// def is the enterprise project default of the ProviderConfig.
+		def string
+		// id is the enterprise project of the managed resource.
+		id *string
+		// observed is the enterprise project of the existing VPC.
+		observed string
+	}
+
+	type want struct {
+		created    string
+		atProvider string
+		upToDate   bool
+		migrated   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unset": {
+			reason: "Should leave the enterprise project alone if neither the managed resource nor the ProviderConfig sets one",
+			args:   args{observed: "0"},
+			want: want{
+				atProvider: "0",
+				upToDate:   true,
+			},
+		},
+		"Default": {
+			reason: "Should create the VPC in and migrate it to the enterprise project default of the ProviderConfig",
+			args:   args{def: "ep-finance", observed: "0"},
+			want: want{
+				created:    "ep-finance",
+				atProvider: "0",
+				migrated:   "ep-finance",
+			},
+		},
+		"Override": {
+			reason: "Should prefer the enterprise project of the managed resource over the default",
+			args:   args{def: "ep-finance", id: pointer.To("ep-network"), observed: "ep-network"},
+			want: want{
+				created:    "ep-network",
+				atProvider: "ep-network",
+				upToDate:   true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testhelper.SetupHTTP()
+			defer testhelper.TeardownHTTP()
+
+			got := want{}
+			testhelper.Mux.HandleFunc("/project/vpcs", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					var body struct {
+						VPC struct {
+							EnterpriseProjectID string `json:"enterprise_project_id"`
+						} `json:"vpc"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Fatal(err)
+					}
+					got.created = body.VPC.EnterpriseProjectID
+					fmt.Fprint(w, `{"vpc":{"id":"vpc-id-123","name":"test-vpc","cidr":"192.168.0.0/16"}}`)
+					return
+				}
+				fmt.Fprint(w, `{"vpcs":[]}`)
+			})
+			testhelper.Mux.HandleFunc("/project/vpcs/vpc-id-123", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "PUT")
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprint(w, `{"vpc":{"id":"vpc-id-123","name":"test-vpc","cidr":"192.168.0.0/16"}}`)
+			})
+			testhelper.Mux.HandleFunc("/project/vpcs/vpc-id-123/tags/action", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+			testhelper.Mux.HandleFunc("/v3/project/vpcs/vpc-id-123", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "GET")
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"vpc":{"id":"vpc-id-123","name":"test-vpc","cidr":"192.168.0.0/16","status":"ACTIVE","enterprise_project_id":%q}}`, tc.args.observed)
+			})
+			testhelper.Mux.HandleFunc("/v1.0/enterprise-projects/", func(w http.ResponseWriter, r *http.Request) {
+				testhelper.TestMethod(t, r, "POST")
+				testhelper.TestJSONRequest(t, r, `{
+					"project_id": "project",
+					"region_id": "eu-de",
+					"resource_id": "vpc-id-123",
+					"resource_type": "vpcs",
+					"associated": false
+				}`)
+				got.migrated = strings.Split(strings.TrimPrefix(r.URL.Path, "/v1.0/enterprise-projects/"), "/")[0]
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			sc := fake.ServiceClient()
+			sc.Endpoint = testhelper.Endpoint()
+			sc.ProjectID = "project"
+
+			v3 := fake.ServiceClient()
+			v3.ResourceBase = testhelper.Endpoint() + "v3/project/"
+
+			e := external{
+				client:            sc,
+				tagClient:         sc,
+				v3Client:          v3,
+				enterpriseProject: tc.args.def,
+				migrator: enterprise.NewMigrator(func() (*golangsdk.ServiceClient, error) {
+					eps := fake.ServiceClient()
+					eps.ProjectID = "project"
+					eps.ResourceBase = testhelper.Endpoint() + "v1.0/"
+					return eps, nil
+				}, "eu-de"),
+			}
+
+			mg := vpc(withSpec("test-vpc", "192.168.0.0/16"))
+			mg.Spec.ForProvider.EnterpriseProjectID = tc.args.id
+			if _, err := e.Create(context.Background(), mg); err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v\n", tc.reason, err)
+			}
+
+			o, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v\n", tc.reason, err)
+			}
+			got.atProvider = mg.Status.AtProvider.EnterpriseProjectID
+			got.upToDate = o.ResourceUpToDate
+
+			if _, err := e.Update(context.Background(), mg); err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v\n", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Create, e.Observe, e.Update: -want, +got:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
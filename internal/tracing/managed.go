@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Span attributes.
+const (
+	AttrController       = attribute.Key("crossplane.controller")
+	AttrNamespace        = attribute.Key("k8s.namespace.name")
+	AttrName             = attribute.Key("crossplane.resource.name")
+	AttrResourceExists   = attribute.Key("crossplane.resource.exists")
+	AttrResourceUpToDate = attribute.Key("crossplane.resource.up_to_date")
+)
+
+// NewReconciler returns a reconciler that records a span for every reconcile
+// of the supplied reconciler.
+func NewReconciler(name string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, span := Start(ctx, "Reconcile",
+			AttrController.String(name),
+			AttrNamespace.String(req.Namespace),
+			AttrName.String(req.Name),
+		)
+		res, err := r.Reconcile(ctx, req)
+		End(span, err)
+		return res, err
+	})
+}
+
+// NewConnector returns a connector that records spans for connecting to the
+// external API and for every operation of the connected client.
+func NewConnector(name string, c managed.ExternalConnector) managed.ExternalConnector {
+	return &connector{name: name, next: c}
+}
+
+type connector struct {
+	name string
+	next managed.ExternalConnector
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ctx, span := c.start(ctx, "Connect", mg)
+	ec, err := c.next.Connect(ctx, mg)
+	End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connector: c, next: ec}, nil
+}
+
+func (c *connector) start(ctx context.Context, op string, mg resource.Managed) (context.Context, trace.Span) {
+	return Start(ctx, op,
+		AttrController.String(c.name),
+		AttrNamespace.String(mg.GetNamespace()),
+		AttrName.String(mg.GetName()),
+	)
+}
+
+type external struct {
+	*connector
+	next managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := e.start(ctx, "Observe", mg)
+	o, err := e.next.Observe(ctx, mg)
+	span.SetAttributes(AttrResourceExists.Bool(o.ResourceExists), AttrResourceUpToDate.Bool(o.ResourceUpToDate))
+	End(span, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := e.start(ctx, "Create", mg)
+	c, err := e.next.Create(ctx, mg)
+	End(span, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, span := e.start(ctx, "Update", mg)
+	u, err := e.next.Update(ctx, mg)
+	End(span, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	ctx, span := e.start(ctx, "Delete", mg)
+	d, err := e.next.Delete(ctx, mg)
+	End(span, err)
+	return d, err
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	c := NewConnector("managed/test", managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+		}, nil
+	}))
+
+	r := NewReconciler("managed/test", reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		mg := &fake.Managed{}
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		_, err = ec.Observe(ctx, mg)
+		return reconcile.Result{}, err
+	}))
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Spans are recorded once they end, children first.
+	spans := sr.Ended()
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	if diff := cmp.Diff([]string{"Connect", "Observe", "Reconcile"}, names); diff != "" {
+		t.Errorf("span names: -want, +got:\n%s\n", diff)
+	}
+
+	root := spans[2].SpanContext().SpanID()
+	for _, s := range spans[:2] {
+		if s.Parent().SpanID() != root {
+			t.Errorf("span %s: want parent Reconcile", s.Name())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package tracing records OpenTelemetry traces of reconciles and of the
+// requests to the OTC APIs.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/peertechde/provider-opentelekomcloud/internal/version"
+)
+
+// TracerName is the name of the tracer of the provider.
+const TracerName = "github.com/peertechde/provider-opentelekomcloud"
+
+// Exporters of traces.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Options configures how traces are exported.
+type Options struct {
+	// Exporter is one of ExporterNone, ExporterOTLP or ExporterStdout.
+	Exporter string
+
+	// Endpoint is the host and port of the OTLP gRPC collector. The
+	// OTEL_EXPORTER_OTLP_* environment variables are used if it is empty.
+	Endpoint string
+
+	// Insecure disables TLS towards the OTLP collector.
+	Insecure bool
+}
+
+// Setup installs the global tracer provider and returns a function that
+// flushes the remaining spans and stops exporting them. Without an exporter
+// the spans are not recorded.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	var exp sdktrace.SpanExporter
+	var err error
+
+	switch o.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if o.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(o.Endpoint))
+		}
+		if o.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf("unknown trace exporter %q", o.Exporter)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create %s trace exporter", o.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("provider-opentelekomcloud"),
+			semconv.ServiceVersion(version.Version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp.Shutdown, nil
+}
+
+// Start starts a span with the tracer of the provider.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the supplied error, if any, and ends the supplied span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}